@@ -0,0 +1,426 @@
+/**
+@file          main.go
+@package       main
+@brief         Merges, filters, and follows gokit log files.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+/*
+Command gokit-log merges a gokit log file and its rotated archives, including compressed archives,
+into one chronological stream, filters it, and optionally follows new lines across rotations like
+`tail -F`.
+
+Usage:
+
+	gokit-log [flags] logfile...
+
+Flags:
+
+	-level warn           Show entries at or above the level.
+	-since 1h             Show entries at or after the RFC 3339 time, date, or duration ago.
+	-until 2026-10-17     Show entries before the time, date, or duration ago.
+	-caller 'scanner/*'   Show entries whose caller matches the glob.
+	-grep 'timeout|EOF'   Show entries whose message and fields match the regular expression.
+	-format text          Print as text, json, or color.
+	-f                    Follow new entries as they're written.
+*/
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/E-B-Smith/gokit/log"
+)
+
+// followInterval is how often followed files are checked for new lines.
+const followInterval = time.Millisecond * 250
+
+func main() {
+	if error := run(os.Args[1:], os.Stdout); error != nil {
+		fmt.Fprintf(os.Stderr, "gokit-log: %v\n", error)
+		os.Exit(1)
+	}
+}
+
+// options are the command line options.
+type options struct {
+	filter    filter
+	format    string
+	follow    bool
+	filenames []string
+}
+
+// parseOptions parses the command line arguments.
+func parseOptions(args []string) (*options, error) {
+	var (
+		opts                       options
+		level, since, until, regex string
+	)
+	flags := flag.NewFlagSet("gokit-log", flag.ContinueOnError)
+	flags.StringVar(&level, "level", "", "Show entries at or above the `level`.")
+	flags.StringVar(&since, "since", "", "Show entries at or after the RFC 3339 `time`, date, or duration ago.")
+	flags.StringVar(&until, "until", "", "Show entries before the RFC 3339 `time`, date, or duration ago.")
+	flags.StringVar(&opts.filter.caller, "caller", "", "Show entries whose caller matches the `glob`.")
+	flags.StringVar(&regex, "grep", "", "Show entries whose message and fields match the `regexp`.")
+	flags.StringVar(&opts.format, "format", "text", "Print entries as text, json, or color.")
+	flags.BoolVar(&opts.follow, "f", false, "Follow new entries as they're written.")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: gokit-log [flags] logfile...\n\n")
+		flags.PrintDefaults()
+	}
+	if error := flags.Parse(args); error != nil {
+		return nil, error
+	}
+	opts.filenames = flags.Args()
+	if len(opts.filenames) == 0 {
+		flags.Usage()
+		return nil, errors.New("no log files")
+	}
+
+	var error error
+	if level != "" {
+		if opts.filter.level = log.LevelFromString(level); opts.filter.level == log.LevelInvalid {
+			return nil, fmt.Errorf("invalid level '%s'", level)
+		}
+	}
+	if opts.filter.since, error = parseTime(since); error != nil {
+		return nil, error
+	}
+	if opts.filter.until, error = parseTime(until); error != nil {
+		return nil, error
+	}
+	if _, error = path.Match(opts.filter.caller, ""); error != nil {
+		return nil, fmt.Errorf("invalid caller glob '%s'", opts.filter.caller)
+	}
+	if regex != "" {
+		if opts.filter.pattern, error = regexp.Compile(regex); error != nil {
+			return nil, error
+		}
+	}
+	switch opts.format {
+	case "text", "json", "color":
+	default:
+		return nil, fmt.Errorf("unknown format '%s'", opts.format)
+	}
+	return &opts, nil
+}
+
+// parseTime parses an RFC 3339 time, a date, or a duration before now.
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if d, error := time.ParseDuration(s); error == nil {
+		return time.Now().Add(-d), nil
+	}
+	if t, error := time.Parse(time.RFC3339Nano, s); error == nil {
+		return t, nil
+	}
+	if t, error := time.ParseInLocation("2006-01-02", s, time.Local); error == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time '%s'", s)
+}
+
+// run merges and prints the log files named in the arguments.
+func run(args []string, output io.Writer) error {
+	opts, error := parseOptions(args)
+	if error != nil {
+		return error
+	}
+	writer := bufio.NewWriter(output)
+	defer writer.Flush()
+	print := func(entry *log.Entry) {
+		if opts.filter.match(entry) {
+			writer.Write(formatEntry(entry, opts.format))
+		}
+	}
+
+	//  Open the live files before listing the archives so that a file rotated in between is
+	//  neither missed nor read twice --
+
+	var (
+		sources []source
+		tails   []*tail
+	)
+	for _, filename := range opts.filenames {
+		live, _ := filepath.Abs(filename)
+		t := &tail{filename: live}
+		if error := t.open(); error != nil && !os.IsNotExist(error) {
+			return error
+		}
+		logfiles, error := log.LogFiles(filename)
+		if error != nil {
+			return error
+		}
+		logfiles = t.archives(logfiles)
+		if len(logfiles) == 0 && t.file == nil && !opts.follow {
+			return fmt.Errorf("no log files for '%s'", filename)
+		}
+		tails = append(tails, t)
+		sources = append(sources, log.NewFileReader(logfiles...), &lineSource{lines: t.readLines()})
+	}
+	if error := merge(sources, print); error != nil {
+		return error
+	}
+	if !opts.follow {
+		for _, t := range tails {
+			t.close()
+		}
+		return nil
+	}
+
+	//  Follow --
+
+	writer.Flush()
+	for {
+		time.Sleep(followInterval)
+		var entries []*log.Entry
+		for _, t := range tails {
+			source := &lineSource{lines: t.readLines()}
+			for source.Next() {
+				entries = append(entries, source.Entry())
+			}
+		}
+		sort.SliceStable(entries, func(i, j int) bool { return entries[i].Time.Before(entries[j].Time) })
+		for _, entry := range entries {
+			print(entry)
+		}
+		if error := writer.Flush(); error != nil {
+			return error
+		}
+	}
+}
+
+// filter selects log entries.
+type filter struct {
+	level   log.Level
+	since   time.Time
+	until   time.Time
+	caller  string
+	pattern *regexp.Regexp
+}
+
+// match returns true if the entry passes the filter.
+func (f *filter) match(entry *log.Entry) bool {
+	if entry.Level < f.level {
+		return false
+	}
+	if (!f.since.IsZero() && entry.Time.Before(f.since)) || (!f.until.IsZero() && !entry.Time.Before(f.until)) {
+		return false
+	}
+	if f.caller != "" {
+		matched, _ := path.Match(f.caller, entry.File)
+		if !matched {
+			matched, _ = path.Match(f.caller, path.Base(entry.File))
+		}
+		if !matched {
+			return false
+		}
+	}
+	if f.pattern != nil {
+		text := entry.Message
+		for _, field := range entry.Fields {
+			text += fmt.Sprintf(" %s=%v", field.Key, field.Value)
+		}
+		if !f.pattern.MatchString(text) {
+			return false
+		}
+	}
+	return true
+}
+
+// source is a chronological source of log entries.
+type source interface {
+	Next() bool
+	Entry() *log.Entry
+}
+
+// merge calls f with the entries of the sources in chronological order.
+func merge(sources []source, f func(*log.Entry)) error {
+	heads := make([]*log.Entry, len(sources))
+	for i, source := range sources {
+		if source.Next() {
+			heads[i] = source.Entry()
+		}
+	}
+	for {
+		next := -1
+		for i, head := range heads {
+			if head != nil && (next < 0 || head.Time.Before(heads[next].Time)) {
+				next = i
+			}
+		}
+		if next < 0 {
+			break
+		}
+		f(heads[next])
+		heads[next] = nil
+		if sources[next].Next() {
+			heads[next] = sources[next].Entry()
+		}
+	}
+	for _, source := range sources {
+		if reader, ok := source.(*log.Reader); ok && reader.Err() != nil {
+			return reader.Err()
+		}
+	}
+	return nil
+}
+
+// lineSource is a source of the entries in lines of text. Lines that aren't log lines are skipped.
+type lineSource struct {
+	lines []string
+	entry *log.Entry
+}
+
+func (source *lineSource) Next() bool {
+	for len(source.lines) > 0 {
+		line := source.lines[0]
+		source.lines = source.lines[1:]
+		if entry, error := log.ParseLine(line); error == nil {
+			source.entry = entry
+			return true
+		}
+	}
+	return false
+}
+
+func (source *lineSource) Entry() *log.Entry {
+	return source.entry
+}
+
+// tail reads the lines added to a live log file, reopening it when it's rotated or truncated.
+type tail struct {
+	filename string
+	file     *os.File
+	reader   *bufio.Reader
+	offset   int64
+	partial  string
+}
+
+func (t *tail) open() error {
+	file, error := os.Open(t.filename)
+	if error != nil {
+		return error
+	}
+	t.file, t.reader, t.offset, t.partial = file, bufio.NewReader(file), 0, ""
+	return nil
+}
+
+// archives returns the log files without the live file. A file that was rotated after the live
+// file was opened is left out too, since it's read through the open file.
+func (t *tail) archives(logfiles []string) []string {
+	var opened os.FileInfo
+	if t.file != nil {
+		opened, _ = t.file.Stat()
+	}
+	var archives []string
+	for _, logfile := range logfiles {
+		if logfile == t.filename {
+			continue
+		}
+		if info, error := os.Stat(logfile); error == nil && opened != nil && os.SameFile(info, opened) {
+			continue
+		}
+		archives = append(archives, logfile)
+	}
+	return archives
+}
+
+func (t *tail) close() {
+	if t.file != nil {
+		t.file.Close()
+		t.file = nil
+	}
+}
+
+// readLines returns the complete lines added since the last read.
+func (t *tail) readLines() []string {
+	if t.file == nil {
+		if t.open() != nil {
+			return nil
+		}
+	}
+	lines := t.readToEnd()
+
+	//  Reopen the file if it was rotated, removed, or truncated --
+
+	info, error := os.Stat(t.filename)
+	current, _ := t.file.Stat()
+	switch {
+	case error != nil:
+	case current == nil || !os.SameFile(info, current):
+		t.close()
+		if t.open() == nil {
+			lines = append(lines, t.readToEnd()...)
+		}
+	case info.Size() < t.offset:
+		t.file.Seek(0, io.SeekStart)
+		t.reader.Reset(t.file)
+		t.offset, t.partial = 0, ""
+		lines = append(lines, t.readToEnd()...)
+	}
+	return lines
+}
+
+// readToEnd reads the complete lines up to the end of the file.
+func (t *tail) readToEnd() []string {
+	var lines []string
+	for {
+		s, error := t.reader.ReadString('\n')
+		t.offset += int64(len(s))
+		if error != nil {
+			t.partial += s
+			return lines
+		}
+		lines = append(lines, strings.TrimRight(t.partial+s, "\r\n"))
+		t.partial = ""
+	}
+}
+
+// Terminal colors for each level.
+var levelColors = map[log.Level]string{
+	log.LevelDebug:   "\x1b[90m",
+	log.LevelInfo:    "\x1b[32m",
+	log.LevelStart:   "\x1b[36m",
+	log.LevelExit:    "\x1b[36m",
+	log.LevelWarning: "\x1b[33m",
+	log.LevelError:   "\x1b[31m",
+}
+
+// formatEntry formats the entry as a line of output.
+func formatEntry(entry *log.Entry, format string) []byte {
+	switch format {
+	case "json":
+		return log.JSONEncoder{}.Encode(entry)
+	case "color":
+		var builder strings.Builder
+		builder.WriteString("\x1b[2m" + entry.Time.Format("2006-01-02 15:04:05") + "\x1b[0m ")
+		builder.WriteString(levelColors[entry.Level])
+		builder.WriteString(fmt.Sprintf("%-7s", log.ShortStringFromLevel(entry.Level)))
+		builder.WriteString("\x1b[0m ")
+		builder.WriteString(fmt.Sprintf("\x1b[2m%s:%d\x1b[0m ", entry.Caller(), entry.Line))
+		builder.WriteString(strings.Replace(entry.Message, "\n", "|", -1))
+		for _, field := range entry.Fields {
+			builder.WriteString(fmt.Sprintf(" \x1b[36m%s\x1b[0m=%v", field.Key, field.Value))
+		}
+		builder.WriteString("\n")
+		return []byte(builder.String())
+	default:
+		return log.TextEncoder{}.Encode(entry)
+	}
+}
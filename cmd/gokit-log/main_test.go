@@ -0,0 +1,137 @@
+/**
+@file          main_test.go
+@package       main
+@brief         Test merging and filtering log files.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/E-B-Smith/gokit/log"
+)
+
+func writeTestLine(t *testing.T, filename string, lines ...string) {
+	file, error := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if error != nil {
+		t.Fatal(error)
+	}
+	defer file.Close()
+	for _, line := range lines {
+		file.WriteString(line + "\n")
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	app := filepath.Join(dir, "app.log")
+	audit := filepath.Join(dir, "audit.log")
+	writeTestLine(t, filepath.Join(dir, "app-2026-10-16T00-00-00-07-00.log"),
+		"2026-10-16T09:00:00-07:00          server/server.go:10   Info: Archived.",
+	)
+	writeTestLine(t, app,
+		"2026-10-17T09:00:00-07:00          server/server.go:10   Info: Started.",
+		"Not a log line.",
+		"2026-10-17T09:00:02-07:00       http/handler.go:22   Warn: Slow request. ms=900",
+	)
+	writeTestLine(t, audit,
+		"2026-10-17T09:00:01-07:00           audit/audit.go:5    Info: Login. user=jane",
+	)
+
+	output := func(args ...string) string {
+		var buffer bytes.Buffer
+		if error := run(args, &buffer); error != nil {
+			t.Fatal(error)
+		}
+		var messages []string
+		for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+			if entry, error := log.ParseLine(line); error == nil {
+				messages = append(messages, entry.Message)
+			}
+		}
+		return strings.Join(messages, " ")
+	}
+	if s := output(app, audit); s != "Archived. Started. Login. user=jane Slow request. ms=900" {
+		t.Errorf("Unexpected merge '%s'.", s)
+	}
+	if s := output("-level", "warn", app, audit); s != "Slow request. ms=900" {
+		t.Errorf("Unexpected level filter '%s'.", s)
+	}
+	if s := output("-since", "2026-10-17T09:00:01-07:00", "-until", "2026-10-17T09:00:02-07:00", app, audit); s != "Login. user=jane" {
+		t.Errorf("Unexpected time filter '%s'.", s)
+	}
+	if s := output("-caller", "server/*", app); s != "Archived. Started." {
+		t.Errorf("Unexpected caller filter '%s'.", s)
+	}
+	if s := output("-grep", `ms=\d+`, "-format", "json", app); s != "Slow request. ms=900" {
+		t.Errorf("Unexpected grep filter '%s'.", s)
+	}
+	var buffer bytes.Buffer
+	if run([]string{"-level", "loud", app}, &buffer) == nil {
+		t.Errorf("Expected an error for an invalid level.")
+	}
+}
+
+func TestTail(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "tail.log")
+	writeTestLine(t, filename, "one")
+	tail := &tail{filename: filename}
+	defer tail.close()
+	if lines := tail.readLines(); strings.Join(lines, " ") != "one" {
+		t.Errorf("Unexpected lines %v.", lines)
+	}
+
+	//  Rotated --
+
+	writeTestLine(t, filename, "two")
+	os.Rename(filename, filename+".1")
+	writeTestLine(t, filename, "three")
+	if lines := tail.readLines(); strings.Join(lines, " ") != "two three" {
+		t.Errorf("Unexpected lines %v.", lines)
+	}
+
+	//  Truncated --
+
+	os.Truncate(filename, 0)
+	writeTestLine(t, filename, "4")
+	if lines := tail.readLines(); strings.Join(lines, " ") != "4" {
+		t.Errorf("Unexpected lines %v.", lines)
+	}
+}
+
+func TestTailArchives(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "app.log")
+	archive := filepath.Join(dir, "app-2026-10-16T00-00-00-07-00.log")
+	rotated := filepath.Join(dir, "app-2026-10-17T00-00-00-07-00.log")
+	writeTestLine(t, archive, "one")
+	writeTestLine(t, filename, "two")
+	tail := &tail{filename: filename}
+	if error := tail.open(); error != nil {
+		t.Fatal(error)
+	}
+	defer tail.close()
+
+	//  Rotated after the live file was opened --
+
+	os.Rename(filename, rotated)
+	writeTestLine(t, filename, "three")
+	logfiles, error := log.LogFiles(filename)
+	if error != nil {
+		t.Fatal(error)
+	}
+	if archives := tail.archives(logfiles); len(archives) != 1 || archives[0] != archive {
+		t.Errorf("Unexpected archives %v.", archives)
+	}
+	if lines := tail.readLines(); strings.Join(lines, " ") != "two three" {
+		t.Errorf("Unexpected lines %v.", lines)
+	}
+}
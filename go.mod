@@ -1,5 +1,5 @@
 module github.com/E-B-Smith/gokit
 
-go 1.20
+go 1.21
 
 require golang.org/x/text v0.7.0
@@ -0,0 +1,145 @@
+/**
+@file          async.go
+@package       log
+@brief         Asynchronous log writing through a bounded queue.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+// OverflowPolicy is what an asynchronous Logger does with a new message when its queue is full.
+type OverflowPolicy int32
+
+const (
+	// OverflowBlock waits until there is room in the queue.
+	OverflowBlock OverflowPolicy = iota
+
+	// OverflowDropNewest drops the new message.
+	OverflowDropNewest
+
+	// OverflowDropOldest drops the oldest queued message to make room for the new message.
+	OverflowDropOldest
+)
+
+// queuedEntry is an encoded entry waiting to be written, or a flush request if flushed isn't nil.
+type queuedEntry struct {
+	entry   *Entry
+	line    []byte
+	flushed chan struct{}
+}
+
+// asyncQueue holds the entries waiting for the writer goroutine.
+type asyncQueue struct {
+	entries chan queuedEntry
+	policy  OverflowPolicy
+	done    chan struct{}
+}
+
+// SetAsync turns on asynchronous writing. Log messages are put in a queue of queueSize messages and
+// written by a single writer goroutine. The policy decides what happens when the queue is full.
+// A queueSize of zero or less writes the queued messages and turns asynchronous writing off.
+func (logger *Logger) SetAsync(queueSize int, policy OverflowPolicy) {
+	defer logger.updateFlush()
+	logger.asyncMutex.Lock()
+	defer logger.asyncMutex.Unlock()
+	if logger.queue != nil {
+		close(logger.queue.entries)
+		<-logger.queue.done
+		logger.queue = nil
+	}
+	if queueSize <= 0 {
+		return
+	}
+	logger.queue = &asyncQueue{
+		entries: make(chan queuedEntry, queueSize),
+		policy:  policy,
+		done:    make(chan struct{}),
+	}
+	go logger.writeQueue(logger.queue)
+}
+
+// Async returns true if log messages are written asynchronously.
+func (logger *Logger) Async() bool {
+	logger.asyncMutex.RLock()
+	defer logger.asyncMutex.RUnlock()
+	return logger.queue != nil
+}
+
+// DroppedMessages returns the number of messages dropped because the asynchronous queue was full.
+func (logger *Logger) DroppedMessages() uint64 {
+	return logger.dropped.Load()
+}
+
+// enqueue queues the encoded entry for the writer goroutine. It returns false if the logger isn't asynchronous.
+func (logger *Logger) enqueue(entry *Entry, line []byte) bool {
+	logger.asyncMutex.RLock()
+	defer logger.asyncMutex.RUnlock()
+	queue := logger.queue
+	if queue == nil {
+		return false
+	}
+	item := queuedEntry{entry: entry, line: line}
+	switch queue.policy {
+	case OverflowDropNewest:
+		select {
+		case queue.entries <- item:
+		default:
+			logger.dropped.Add(1)
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case queue.entries <- item:
+				return true
+			default:
+			}
+			select {
+			case oldest := <-queue.entries:
+				if oldest.flushed != nil {
+					//  Everything before a flush request has been written or dropped --
+					close(oldest.flushed)
+				} else {
+					logger.dropped.Add(1)
+				}
+			default:
+			}
+		}
+	default:
+		queue.entries <- item
+	}
+	return true
+}
+
+// writeQueue writes the queued entries until the queue is closed.
+func (logger *Logger) writeQueue(queue *asyncQueue) {
+	defer close(queue.done)
+	for item := range queue.entries {
+		if item.flushed != nil {
+			close(item.flushed)
+			continue
+		}
+		rotatedPath := logger.writeEncoded(item.entry, item.line)
+		if rotatedPath != "" {
+			//  Archiving logs messages, so it can't wait on the queue it feeds --
+			logger.archiving.Add(1)
+			go func() {
+				defer logger.archiving.Done()
+				logger.archiveRotatedLogFile(rotatedPath)
+			}()
+		}
+	}
+}
+
+// drainQueue waits until the messages queued so far have been written.
+func (logger *Logger) drainQueue() {
+	logger.asyncMutex.RLock()
+	defer logger.asyncMutex.RUnlock()
+	if logger.queue == nil {
+		return
+	}
+	flushed := make(chan struct{})
+	logger.queue.entries <- queuedEntry{flushed: flushed}
+	<-flushed
+}
@@ -0,0 +1,149 @@
+/**
+@file          async_test.go
+@package       log
+@brief         Test asynchronous log writing.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func countLines(t *testing.T, filename string) int {
+	b, error := os.ReadFile(filename)
+	if error != nil {
+		t.Fatal(error)
+	}
+	return strings.Count(string(b), "\n")
+}
+
+func TestAsyncBlock(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "async.log")
+	logger := NewLogger()
+	logger.SetFilename(filename)
+	logger.SetAsync(16, OverflowBlock)
+
+	var group sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		group.Add(1)
+		go func(i int) {
+			defer group.Done()
+			for j := 0; j < 250; j++ {
+				logger.Infof("Writer %d message %d.", i, j)
+			}
+		}(i)
+	}
+	group.Wait()
+	logger.FlushMessages()
+
+	if n := countLines(t, filename); n != 1000 {
+		t.Errorf("Expected 1000 lines, found %d.", n)
+	}
+	if logger.DroppedMessages() != 0 {
+		t.Errorf("Expected no dropped messages, found %d.", logger.DroppedMessages())
+	}
+	logger.SetAsync(0, OverflowBlock)
+	logger.SetFilename("")
+}
+
+func TestAsyncDrop(t *testing.T) {
+	for _, policy := range []OverflowPolicy{OverflowDropNewest, OverflowDropOldest} {
+		filename := filepath.Join(t.TempDir(), "drop.log")
+		logger := NewLogger()
+		logger.SetFilename(filename)
+		logger.SetAsync(2, policy)
+
+		//  Stall the writer goroutine so the queue fills --
+
+		logger.writeMutex.Lock()
+		for i := 0; i < 10; i++ {
+			logger.Infof("Message %d.", i)
+		}
+		logger.writeMutex.Unlock()
+		logger.FlushMessages()
+
+		b, _ := os.ReadFile(filename)
+		written := uint64(strings.Count(string(b), "\n"))
+		if written+logger.DroppedMessages() != 10 || logger.DroppedMessages() < 7 {
+			t.Errorf("Policy %d: unexpected %d written and %d dropped.", policy, written, logger.DroppedMessages())
+		}
+		if policy == OverflowDropNewest && !strings.Contains(string(b), "Message 0.") {
+			t.Errorf("Policy %d: expected the first message, found:\n%s", policy, b)
+		}
+		if policy == OverflowDropOldest && !strings.Contains(string(b), "Message 9.") {
+			t.Errorf("Policy %d: expected the last message, found:\n%s", policy, b)
+		}
+		logger.SetAsync(0, policy)
+		logger.SetFilename("")
+	}
+}
+
+func TestAsyncRotationRace(t *testing.T) {
+	dir := t.TempDir()
+	logger := NewLogger()
+	logger.SetRotationInterval(0)
+	logger.SetRetentionCount(0)
+	logger.SetMaxFileSize(1000)
+	logger.SetFilename(filepath.Join(dir, "race.log"))
+	logger.SetAsync(16, OverflowBlock)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 200; i++ {
+			logger.Infof("Message %d.", i)
+		}
+	}()
+	for running := true; running; {
+		select {
+		case <-done:
+			running = false
+		default:
+			if logger.Filename() != filepath.Join(dir, "race.log") {
+				t.Fatalf("Unexpected filename '%s'.", logger.Filename())
+			}
+			logger.flushAll()
+		}
+	}
+	logger.SetAsync(0, OverflowBlock)
+	logger.flushAll()
+	logger.SetFilename("")
+}
+
+func TestAsyncSetFilename(t *testing.T) {
+	dir := t.TempDir()
+	logger := NewLogger()
+	logger.SetFilename(filepath.Join(dir, "a.log"))
+	logger.SetAsync(100, OverflowBlock)
+
+	//  Stall the writer goroutine so the messages are still queued when the file changes --
+
+	logger.writeMutex.Lock()
+	for i := 0; i < 10; i++ {
+		logger.Infof("First %d.", i)
+	}
+	go func() {
+		time.Sleep(time.Millisecond * 50)
+		logger.writeMutex.Unlock()
+	}()
+	logger.SetFilename(filepath.Join(dir, "b.log"))
+	logger.Infof("Second.")
+	logger.SetAsync(0, OverflowBlock)
+	logger.SetFilename("")
+
+	if n := countLines(t, filepath.Join(dir, "a.log")); n != 10 {
+		t.Errorf("Expected 10 lines in a.log, found %d.", n)
+	}
+	if n := countLines(t, filepath.Join(dir, "b.log")); n != 1 {
+		t.Errorf("Expected 1 line in b.log, found %d.", n)
+	}
+}
@@ -0,0 +1,112 @@
+/**
+@file          compress.go
+@package       log
+@brief         Compresses rotated log files.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"sync"
+)
+
+const (
+	// compressSuffix is appended to the name of a compressed log file.
+	compressSuffix = ".gz"
+
+	// compressTempSuffix is appended to the name of a log file while it's being compressed.
+	compressTempSuffix = ".gz.tmp"
+)
+
+// archiveGroup counts the goroutines that are archiving rotated log files. Unlike a sync.WaitGroup
+// it can be added to while another goroutine waits, which happens when the asynchronous writer
+// rotates the log during Shutdown.
+type archiveGroup struct {
+	mutex sync.Mutex
+	done  *sync.Cond
+	count int
+}
+
+// Add adds delta to the number of archiving goroutines.
+func (group *archiveGroup) Add(delta int) {
+	group.mutex.Lock()
+	defer group.mutex.Unlock()
+	group.count += delta
+	if group.count <= 0 && group.done != nil {
+		group.done.Broadcast()
+	}
+}
+
+// Done marks an archiving goroutine finished.
+func (group *archiveGroup) Done() {
+	group.Add(-1)
+}
+
+// Running returns true if any archiving goroutines are running.
+func (group *archiveGroup) Running() bool {
+	group.mutex.Lock()
+	defer group.mutex.Unlock()
+	return group.count > 0
+}
+
+// Wait waits until no archiving goroutines are running.
+func (group *archiveGroup) Wait() {
+	group.mutex.Lock()
+	defer group.mutex.Unlock()
+	if group.done == nil {
+		group.done = sync.NewCond(&group.mutex)
+	}
+	for group.count > 0 {
+		group.done.Wait()
+	}
+}
+
+// compressLogFile compresses the rotated log file to filename.gz and removes the original.
+// The compressed file keeps the modification time of the original so retention still orders it correctly.
+func compressLogFile(filename string) error {
+	source, error := os.Open(filename)
+	if error != nil {
+		return error
+	}
+	defer source.Close()
+	info, error := source.Stat()
+	if error != nil {
+		return error
+	}
+
+	tempname := filename + compressTempSuffix
+	target, error := os.OpenFile(tempname, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, info.Mode().Perm())
+	if error != nil {
+		return error
+	}
+	writer := gzip.NewWriter(target)
+	writer.Name = info.Name()
+	writer.ModTime = info.ModTime()
+	_, error = io.Copy(writer, source)
+	if error == nil {
+		error = writer.Close()
+	}
+	if error == nil {
+		error = target.Sync()
+	}
+	if closeError := target.Close(); error == nil {
+		error = closeError
+	}
+	if error != nil {
+		os.Remove(tempname)
+		return error
+	}
+
+	os.Chtimes(tempname, info.ModTime(), info.ModTime())
+	if error = os.Rename(tempname, filename+compressSuffix); error != nil {
+		os.Remove(tempname)
+		return error
+	}
+	return os.Remove(filename)
+}
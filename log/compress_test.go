@@ -0,0 +1,57 @@
+/**
+@file          compress_test.go
+@package       log
+@brief         Test compression of rotated log files.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCompressRotated(t *testing.T) {
+	dir := t.TempDir()
+	logger := NewLogger()
+	logger.SetRotationInterval(0)
+	logger.SetRetentionCount(3)
+	logger.SetMaxFileSize(4000)
+	logger.SetCompressRotated(true)
+	logger.SetFilename(filepath.Join(dir, "zip.log"))
+	for i := 0; i < 300; i++ {
+		logger.Infof("Message %d.", i)
+	}
+	logger.archiving.Wait()
+	logger.SetFilename("")
+
+	archives, _ := filepath.Glob(filepath.Join(dir, "zip-*"))
+	if len(archives) != 3 {
+		t.Errorf("Expected 3 archives, found %d: %v.", len(archives), archives)
+	}
+	for _, archive := range archives {
+		if !strings.HasSuffix(archive, ".log.gz") {
+			t.Errorf("Expected a compressed archive, found '%s'.", archive)
+			continue
+		}
+		file, _ := os.Open(archive)
+		reader, error := gzip.NewReader(file)
+		if error != nil {
+			t.Errorf("Can't read '%s': %v.", archive, error)
+			file.Close()
+			continue
+		}
+		b, _ := io.ReadAll(reader)
+		file.Close()
+		if !strings.Contains(string(b), " Info: ") {
+			t.Errorf("Expected messages in '%s', found:\n%s", archive, b)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+/**
+@file          config.go
+@package       log
+@brief         Configures a logger from environment variables or a configuration file.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/E-B-Smith/gokit/scanner"
+)
+
+// DefaultEnvPrefix is the prefix of the environment variables read by ConfigureFromEnv.
+const DefaultEnvPrefix = "GOKIT_LOG"
+
+// LogConfig holds the settings read by ConfigureFromEnv and ConfigureFromFile. Empty values leave
+// the logger's settings unchanged.
+type LogConfig struct {
+	// Level is the log level, as a name like "LevelDebug" or a short name like "debug" or "warn".
+	Level string
+
+	// Overrides are per-package levels in the format used by SetLevelOverrides.
+	Overrides string
+
+	// File is the log file name. "stderr" logs to Stderr.
+	File string
+
+	// Rotate is the rotation interval as a duration like "24h", or "daily", "hourly", or "never".
+	Rotate string
+
+	// Retain is the number of rotated files kept, or a duration like "168h" after which they're removed.
+	Retain string
+
+	// Format is the log line format, "text" or "json".
+	Format string
+
+	// Tee is "true" to write log messages to Stderr as well as the log file, or "console" to write
+	// them to Stderr with a ConsoleEncoder.
+	Tee string
+}
+
+// ConfigureFromEnv configures the logger from environment variables named with the prefix, which
+// is DefaultEnvPrefix if it's empty:
+//
+//	GOKIT_LOG_LEVEL=debug
+//	GOKIT_LOG_OVERRIDES=scanner=debug,*/http/*=warn
+//	GOKIT_LOG_FILE=~/logs/app.log
+//	GOKIT_LOG_ROTATE=24h
+//	GOKIT_LOG_RETAIN=7
+//	GOKIT_LOG_FORMAT=json
+//	GOKIT_LOG_TEE=true
+//
+// Unset variables leave the settings unchanged. Nothing is changed if any value is invalid.
+func (logger *Logger) ConfigureFromEnv(prefix string) error {
+	if prefix == "" {
+		prefix = DefaultEnvPrefix
+	}
+	prefix = strings.TrimSuffix(prefix, "_") + "_"
+	config := LogConfig{
+		Level:     os.Getenv(prefix + "LEVEL"),
+		Overrides: os.Getenv(prefix + "OVERRIDES"),
+		File:      os.Getenv(prefix + "FILE"),
+		Rotate:    os.Getenv(prefix + "ROTATE"),
+		Retain:    os.Getenv(prefix + "RETAIN"),
+		Format:    os.Getenv(prefix + "FORMAT"),
+		Tee:       os.Getenv(prefix + "TEE"),
+	}
+	return logger.Configure(config)
+}
+
+// ConfigureFromEnv configures the default Logger from environment variables.
+func ConfigureFromEnv(prefix string) error { return defaultLogger.ConfigureFromEnv(prefix) }
+
+// ConfigureFromFile configures the logger from a configuration file that's read with
+// scanner.ScanInterface. Values that contain punctuation are quoted:
+//
+//	level       debug
+//	file        "~/logs/app.log"
+//	rotate      "24h"
+//	retain      7
+//	format      json
+//	tee         true
+//
+// Nothing is changed if the file can't be read or any value is invalid.
+func (logger *Logger) ConfigureFromFile(filename string) error {
+	file, error := os.Open(absolutePath(filename))
+	if error != nil {
+		return error
+	}
+	defer file.Close()
+	var config LogConfig
+	if error = scanner.NewScannerWithFile(file).ScanInterface(&config); error != nil {
+		return fmt.Errorf("can't read log configuration '%s': %w", filename, error)
+	}
+	return logger.Configure(config)
+}
+
+// ConfigureFromFile configures the default Logger from a configuration file.
+func ConfigureFromFile(filename string) error { return defaultLogger.ConfigureFromFile(filename) }
+
+// Configure checks the configuration and then applies its values to the logger.
+func (logger *Logger) Configure(config LogConfig) error {
+	var changes []func()
+
+	if s := strings.TrimSpace(config.Level); s != "" {
+		level := LevelFromString(s)
+		if level == LevelInvalid {
+			return fmt.Errorf("invalid log level '%s'", s)
+		}
+		changes = append(changes, func() { logger.SetLogLevel(level) })
+	}
+	if s := strings.TrimSpace(config.Overrides); s != "" {
+		if _, error := parseLevelOverrides(s); error != nil {
+			return error
+		}
+		changes = append(changes, func() { logger.SetLevelOverrides(s) })
+	}
+	if s := strings.TrimSpace(config.Rotate); s != "" {
+		var interval time.Duration
+		switch strings.ToLower(s) {
+		case "daily":
+			interval = time.Hour * 24
+		case "hourly":
+			interval = time.Hour
+		case "never", "off", "0":
+		default:
+			var error error
+			if interval, error = time.ParseDuration(s); error != nil || interval < 0 {
+				return fmt.Errorf("invalid log rotation interval '%s'", s)
+			}
+		}
+		changes = append(changes, func() { logger.SetRotationInterval(interval) })
+	}
+	if s := strings.TrimSpace(config.Retain); s != "" {
+		if count, error := strconv.Atoi(s); error == nil && count >= 0 {
+			changes = append(changes, func() { logger.SetRetentionCount(count) })
+		} else if age, error := time.ParseDuration(s); error == nil && age > 0 {
+			changes = append(changes, func() {
+				logger.SetRetentionCount(0)
+				logger.SetRetentionMaxAge(age)
+			})
+		} else {
+			return fmt.Errorf("invalid log retention '%s'", s)
+		}
+	}
+	if s := strings.TrimSpace(config.Format); s != "" {
+		var encoder Encoder
+		switch strings.ToLower(s) {
+		case "text":
+			encoder = TextEncoder{}
+		case "json":
+			encoder = JSONEncoder{}
+		default:
+			return fmt.Errorf("invalid log format '%s'", s)
+		}
+		changes = append(changes, func() { logger.SetEncoder(encoder) })
+	}
+	if s := strings.TrimSpace(config.Tee); strings.EqualFold(s, "console") {
+		changes = append(changes, func() {
+			logger.SetTeeEncoder(NewConsoleEncoder())
+			logger.SetTeeStderr(true)
+		})
+	} else if s != "" {
+		tee, error := strconv.ParseBool(s)
+		if error != nil {
+			return fmt.Errorf("invalid log tee value '%s'", s)
+		}
+		changes = append(changes, func() { logger.SetTeeStderr(tee) })
+	}
+
+	//  Set the file last so that it's opened with the new settings --
+
+	if s := strings.TrimSpace(config.File); s != "" {
+		if strings.EqualFold(s, "stderr") {
+			s = ""
+		}
+		changes = append(changes, func() { logger.SetFilename(s) })
+	}
+
+	for _, change := range changes {
+		change()
+	}
+	return nil
+}
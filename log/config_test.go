@@ -0,0 +1,76 @@
+/**
+@file          config_test.go
+@package       log
+@brief         Test configuring a logger from the environment and a file.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestConfigureFromEnv(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("APP_LOG_LEVEL", "warn")
+	t.Setenv("APP_LOG_FILE", filepath.Join(dir, "env.log"))
+	t.Setenv("APP_LOG_ROTATE", "hourly")
+	t.Setenv("APP_LOG_RETAIN", "168h")
+	t.Setenv("APP_LOG_FORMAT", "json")
+	t.Setenv("APP_LOG_TEE", "false")
+
+	logger := NewLogger()
+	if error := logger.ConfigureFromEnv("APP_LOG"); error != nil {
+		t.Fatal(error)
+	}
+	defer logger.SetFilename("")
+	if logger.LogLevel() != LevelWarning || logger.Filename() != filepath.Join(dir, "env.log") ||
+		logger.RotationInterval() != time.Hour || logger.RetentionCount() != 0 ||
+		logger.RetentionMaxAge() != time.Hour*168 || logger.Encoder() != (JSONEncoder{}) {
+		t.Errorf("Unexpected configuration %+v.", logger.Config())
+	}
+
+	t.Setenv("APP_LOG_LEVEL", "LevelDebug")
+	t.Setenv("APP_LOG_TEE", "maybe")
+	if logger.ConfigureFromEnv("APP_LOG") == nil {
+		t.Errorf("Expected an error for an invalid tee value.")
+	}
+	if logger.LogLevel() != LevelWarning {
+		t.Errorf("An invalid configuration shouldn't change the settings.")
+	}
+}
+
+func TestConfigureFromFile(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "log.conf")
+	os.WriteFile(filename, []byte(`
+level       LevelDebug
+overrides   "scanner=warn"
+file        "`+filepath.Join(dir, "file.log")+`"
+rotate      "36h"
+retain      3
+tee         true
+`), 0600)
+
+	logger := NewLogger()
+	if error := logger.ConfigureFromFile(filename); error != nil {
+		t.Fatal(error)
+	}
+	defer logger.SetFilename("")
+	if logger.LogLevel() != LevelDebug || logger.LevelOverrides() != "scanner=warning" ||
+		logger.Filename() != filepath.Join(dir, "file.log") || logger.RotationInterval() != time.Hour*36 ||
+		logger.RetentionCount() != 3 || !logger.TeeStderr() {
+		t.Errorf("Unexpected configuration %+v.", logger.Config())
+	}
+
+	os.WriteFile(filename, []byte("verbosity high\n"), 0600)
+	if logger.ConfigureFromFile(filename) == nil {
+		t.Errorf("Expected an error for an unknown setting.")
+	}
+}
@@ -0,0 +1,151 @@
+/**
+@file          console.go
+@package       log
+@brief         A colored, human friendly encoder for log messages teed to a terminal.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// consoleCallerWidth is the width that callers are padded to so that messages line up.
+const consoleCallerWidth = 32
+
+// Terminal escape sequences used by the ConsoleEncoder.
+const (
+	colorReset = "\x1b[0m"
+	colorDim   = "\x1b[2m"
+	colorKey   = "\x1b[36m"
+)
+
+// consoleLevels are the level names and colors written by the ConsoleEncoder.
+var consoleLevels = []struct{ name, color string }{
+	{"INVAL", "\x1b[31m"},
+	{"ALL  ", "\x1b[90m"},
+	{"DEBUG", "\x1b[90m"},
+	{"INFO ", "\x1b[32m"},
+	{"START", "\x1b[36m"},
+	{"EXIT ", "\x1b[36m"},
+	{"WARN ", "\x1b[33m"},
+	{"ERROR", "\x1b[1;31m"},
+	{"NONE ", "\x1b[90m"},
+}
+
+// ConsoleEncoder encodes entries for reading on a terminal. The time is shortened, the level is
+// colored, callers are aligned, and each field is written on its own line with structured values
+// pretty printed:
+//
+//	09:30:00.000 WARN  scanner/scanner.go:42            Two
+//	                                                    lines.
+//	    user = 7
+//	    err  = failed
+type ConsoleEncoder struct {
+	// Color is true to color the output with terminal escape sequences.
+	Color bool
+}
+
+// NewConsoleEncoder returns a ConsoleEncoder that colors its output if Stderr is a terminal and
+// the NO_COLOR environment variable isn't set.
+func NewConsoleEncoder() ConsoleEncoder {
+	return ConsoleEncoder{Color: stderrIsTerminal() && os.Getenv("NO_COLOR") == ""}
+}
+
+// stderrIsTerminal returns true if Stderr is a terminal.
+func stderrIsTerminal() bool {
+	info, error := os.Stderr.Stat()
+	return error == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// Encode formats an entry as one or more lines of text.
+func (encoder ConsoleEncoder) Encode(entry *Entry) []byte {
+	color := func(code string) string {
+		if encoder.Color {
+			return code
+		}
+		return ""
+	}
+	level := consoleLevels[LevelInvalid]
+	if entry.Level >= LevelInvalid && int(entry.Level) < len(consoleLevels) {
+		level = consoleLevels[entry.Level]
+	}
+	caller := entry.Caller() + ":" + strconv.Itoa(entry.Line)
+	if len(caller) < consoleCallerWidth {
+		caller += strings.Repeat(" ", consoleCallerWidth-len(caller))
+	}
+
+	var builder strings.Builder
+	builder.WriteString(color(colorDim) + entry.Time.Format("15:04:05.000") + color(colorReset) + " ")
+	builder.WriteString(color(level.color) + level.name + color(colorReset) + " ")
+	builder.WriteString(color(colorDim) + caller + color(colorReset) + " ")
+	indent := "\n" + strings.Repeat(" ", len("15:04:05.000 LEVEL ")+len(caller)+1)
+	message := strings.Replace(strings.TrimRight(entry.Message, "\r\n"), "\r", "", -1)
+	builder.WriteString(strings.Replace(message, "\n", indent, -1))
+	builder.WriteByte('\n')
+
+	width := 0
+	for _, field := range entry.Fields {
+		if len(field.Key) > width {
+			width = len(field.Key)
+		}
+	}
+	for _, field := range entry.Fields {
+		builder.WriteString("    " + color(colorKey) + field.Key + color(colorReset))
+		builder.WriteString(strings.Repeat(" ", width-len(field.Key)) + " = ")
+		value := consoleFieldValue(field.Value)
+		builder.WriteString(strings.Replace(value, "\n", "\n"+strings.Repeat(" ", width+7), -1))
+		builder.WriteByte('\n')
+	}
+	return []byte(builder.String())
+}
+
+// consoleFieldValue returns the field value as a string. Maps, slices, and structs without their
+// own string form are written as indented JSON.
+func consoleFieldValue(value interface{}) string {
+	switch value.(type) {
+	case error, fmt.Stringer, json.Marshaler:
+		return fieldValueString(value)
+	}
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+		if data, error := json.MarshalIndent(value, "", "  "); error == nil {
+			return string(data)
+		}
+	}
+	return fieldValueString(value)
+}
+
+// SetTeeEncoder sets the encoder that formats the messages teed to Stderr when TeeStderr is true.
+// The log file and sinks keep the logger's Encoder. A nil encoder, the default, tees the same
+// lines that are written to the log file:
+//
+//	log.SetTeeEncoder(log.NewConsoleEncoder())
+func (logger *Logger) SetTeeEncoder(encoder Encoder) {
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	logger.teeEncoder = encoder
+}
+
+// TeeEncoder returns the encoder that formats the messages teed to Stderr, or nil if the log file
+// lines are teed.
+func (logger *Logger) TeeEncoder() Encoder {
+	logger.mutex.RLock()
+	defer logger.mutex.RUnlock()
+	return logger.teeEncoder
+}
+
+// SetTeeEncoder sets the encoder that formats the default Logger's messages teed to Stderr.
+func SetTeeEncoder(encoder Encoder) { defaultLogger.SetTeeEncoder(encoder) }
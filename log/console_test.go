@@ -0,0 +1,73 @@
+/**
+@file          console_test.go
+@package       log
+@brief         Test the console encoder and the Stderr tee.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConsoleEncoder(t *testing.T) {
+	entry := testEntry()
+	entry.Fields = append(entry.Fields, Field{Key: "tags", Value: map[string]int{"a": 1}})
+	line := string(ConsoleEncoder{}.Encode(entry))
+	expect := "09:30:00.000 WARN  scanner/scanner.go:42            Two\n" +
+		"                                                    lines.\n" +
+		"    user = 7\n" +
+		"    err  = failed\n" +
+		"    tags = {\n" +
+		"             \"a\": 1\n" +
+		"           }\n"
+	if line != expect {
+		t.Errorf("Expected\n%s\nbut got\n%s", expect, line)
+	}
+
+	colored := string(ConsoleEncoder{Color: true}.Encode(entry))
+	if !strings.Contains(colored, "\x1b[33mWARN \x1b[0m") || !strings.Contains(colored, "\x1b[36muser\x1b[0m") {
+		t.Errorf("Expected a colored level and key but got %q.", colored)
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	if NewConsoleEncoder().Color {
+		t.Errorf("Expected no color with NO_COLOR set.")
+	}
+}
+
+func TestTeeEncoder(t *testing.T) {
+	dir := t.TempDir()
+	stderr, error := os.Create(filepath.Join(dir, "stderr"))
+	if error != nil {
+		t.Fatal(error)
+	}
+	saved := os.Stderr
+	os.Stderr = stderr
+	defer func() { os.Stderr = saved }()
+
+	logger := NewLogger()
+	logger.SetFilename(filepath.Join(dir, "tee.log"))
+	defer logger.SetFilename("")
+	logger.SetTeeStderr(true)
+	logger.SetTeeEncoder(ConsoleEncoder{})
+	logger.Info("Hello.", "user", 7)
+	logger.FlushMessages()
+	os.Stderr = saved
+	stderr.Close()
+
+	file, _ := os.ReadFile(filepath.Join(dir, "tee.log"))
+	if !strings.Contains(string(file), " Info: Hello. user=7\n") {
+		t.Errorf("Expected the log file format but got %q.", file)
+	}
+	tee, _ := os.ReadFile(stderr.Name())
+	if !strings.Contains(string(tee), " INFO  log/console_test.go:") || !strings.HasSuffix(string(tee), "Hello.\n    user = 7\n") {
+		t.Errorf("Expected the console format but got %q.", tee)
+	}
+}
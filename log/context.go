@@ -0,0 +1,221 @@
+/**
+@file          context.go
+@package       log
+@brief         Context aware logging with request scoped fields such as trace and request IDs.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"context"
+	"sync"
+)
+
+// contextKey is the type of the context keys used by the log package.
+type contextKey int
+
+const (
+	loggerContextKey contextKey = iota
+	traceIDContextKey
+	spanIDContextKey
+	requestIDContextKey
+)
+
+// ContextExtractor returns the fields for the request scoped values in a context, such as a trace
+// ID. It returns nil if the context doesn't have any of its values.
+type ContextExtractor func(ctx context.Context) []Field
+
+var contextExtractors = struct {
+	sync.RWMutex
+	extractors []*ContextExtractor
+}{}
+
+// AddContextExtractor registers an extractor that adds fields from the context to each message
+// logged with a context, and returns a function that removes it again. The trace, span, and request
+// IDs set with ContextWithTraceID, ContextWithSpanID, and ContextWithRequestID are always extracted.
+func AddContextExtractor(extractor ContextExtractor) (remove func()) {
+	registered := &extractor
+	contextExtractors.Lock()
+	defer contextExtractors.Unlock()
+	contextExtractors.extractors = append(contextExtractors.extractors, registered)
+	return func() {
+		contextExtractors.Lock()
+		defer contextExtractors.Unlock()
+		extractors := contextExtractors.extractors[:0:0]
+		for _, e := range contextExtractors.extractors {
+			if e != registered {
+				extractors = append(extractors, e)
+			}
+		}
+		contextExtractors.extractors = extractors
+	}
+}
+
+// contextFields returns the fields extracted from the context by the registered extractors.
+func contextFields(ctx context.Context) []interface{} {
+	if ctx == nil {
+		return nil
+	}
+	var result []interface{}
+	for _, field := range extractContextIDs(ctx) {
+		result = append(result, field)
+	}
+	contextExtractors.RLock()
+	defer contextExtractors.RUnlock()
+	for _, extractor := range contextExtractors.extractors {
+		for _, field := range (*extractor)(ctx) {
+			result = append(result, field)
+		}
+	}
+	return result
+}
+
+// extractContextIDs returns the trace, span, and request ID fields of the context.
+func extractContextIDs(ctx context.Context) []Field {
+	var fields []Field
+	for _, id := range []struct {
+		key  contextKey
+		name string
+	}{
+		{traceIDContextKey, "trace_id"},
+		{spanIDContextKey, "span_id"},
+		{requestIDContextKey, "request_id"},
+	} {
+		if value, ok := ctx.Value(id.key).(string); ok && value != "" {
+			fields = append(fields, Field{Key: id.name, Value: value})
+		}
+	}
+	return fields
+}
+
+// ContextWithTraceID returns a context with a trace ID that's logged as the trace_id field.
+func ContextWithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDContextKey, traceID)
+}
+
+// ContextWithSpanID returns a context with a span ID that's logged as the span_id field.
+func ContextWithSpanID(ctx context.Context, spanID string) context.Context {
+	return context.WithValue(ctx, spanIDContextKey, spanID)
+}
+
+// ContextWithRequestID returns a context with a request ID that's logged as the request_id field.
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey, requestID)
+}
+
+// TraceIDFromContext returns the context's trace ID, or the empty string if it doesn't have one.
+func TraceIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(traceIDContextKey).(string)
+	return id
+}
+
+// SpanIDFromContext returns the context's span ID, or the empty string if it doesn't have one.
+func SpanIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(spanIDContextKey).(string)
+	return id
+}
+
+// RequestIDFromContext returns the context's request ID, or the empty string if it doesn't have one.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDContextKey).(string)
+	return id
+}
+
+// WithContext returns a context that carries the logger.
+func WithContext(ctx context.Context, logger *Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey, logger)
+}
+
+// FromContext returns the logger carried by the context, or the default Logger if it doesn't
+// carry one.
+func FromContext(ctx context.Context) *Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(loggerContextKey).(*Logger); ok && logger != nil {
+			return logger
+		}
+	}
+	return defaultLogger
+}
+
+// logContext logs a message with the logger's fields, the fields extracted from the context, and
+// the key / value pairs in keyvals.
+func (logger *Logger) logContext(ctx context.Context, logLevel Level, stackDepth int, message string, keyvals []interface{}) {
+	if !logger.enabled(logLevel, stackDepth+1) {
+		return
+	}
+	fields := appendFields(logger.fields, contextFields(ctx))
+	logger.logEntry(logLevel, stackDepth+1, message, appendFields(fields, keyvals))
+}
+
+// LogContext writes a message with the context's fields and key / value fields at the passed level to the log.
+func (logger *Logger) LogContext(ctx context.Context, level Level, message string, keyvals ...interface{}) {
+	logger.logContext(ctx, level, 2, message, keyvals)
+}
+
+// DebugContext writes a debug level message with the context's fields and key / value fields to the log.
+func (logger *Logger) DebugContext(ctx context.Context, message string, keyvals ...interface{}) {
+	logger.logContext(ctx, LevelDebug, 2, message, keyvals)
+}
+
+// StartContext writes a start level message with the context's fields and key / value fields to the log.
+func (logger *Logger) StartContext(ctx context.Context, message string, keyvals ...interface{}) {
+	logger.logContext(ctx, LevelStart, 2, message, keyvals)
+}
+
+// ExitContext writes an exit level message with the context's fields and key / value fields to the log.
+func (logger *Logger) ExitContext(ctx context.Context, message string, keyvals ...interface{}) {
+	logger.logContext(ctx, LevelExit, 2, message, keyvals)
+}
+
+// InfoContext writes an info level message with the context's fields and key / value fields to the log.
+func (logger *Logger) InfoContext(ctx context.Context, message string, keyvals ...interface{}) {
+	logger.logContext(ctx, LevelInfo, 2, message, keyvals)
+}
+
+// WarningContext writes a warning level message with the context's fields and key / value fields to the log.
+func (logger *Logger) WarningContext(ctx context.Context, message string, keyvals ...interface{}) {
+	logger.logContext(ctx, LevelWarning, 2, message, keyvals)
+}
+
+// ErrorContext writes an error level message with the context's fields and key / value fields to the log.
+func (logger *Logger) ErrorContext(ctx context.Context, message string, keyvals ...interface{}) {
+	logger.logContext(ctx, LevelError, 2, message, keyvals)
+}
+
+// LogContext writes a message at the passed level to the context's logger.
+func LogContext(ctx context.Context, level Level, message string, keyvals ...interface{}) {
+	FromContext(ctx).logContext(ctx, level, 2, message, keyvals)
+}
+
+// DebugContext writes a debug level message to the context's logger.
+func DebugContext(ctx context.Context, message string, keyvals ...interface{}) {
+	FromContext(ctx).logContext(ctx, LevelDebug, 2, message, keyvals)
+}
+
+// StartContext writes a start level message to the context's logger.
+func StartContext(ctx context.Context, message string, keyvals ...interface{}) {
+	FromContext(ctx).logContext(ctx, LevelStart, 2, message, keyvals)
+}
+
+// ExitContext writes an exit level message to the context's logger.
+func ExitContext(ctx context.Context, message string, keyvals ...interface{}) {
+	FromContext(ctx).logContext(ctx, LevelExit, 2, message, keyvals)
+}
+
+// InfoContext writes an info level message to the context's logger.
+func InfoContext(ctx context.Context, message string, keyvals ...interface{}) {
+	FromContext(ctx).logContext(ctx, LevelInfo, 2, message, keyvals)
+}
+
+// WarningContext writes a warning level message to the context's logger.
+func WarningContext(ctx context.Context, message string, keyvals ...interface{}) {
+	FromContext(ctx).logContext(ctx, LevelWarning, 2, message, keyvals)
+}
+
+// ErrorContext writes an error level message to the context's logger.
+func ErrorContext(ctx context.Context, message string, keyvals ...interface{}) {
+	FromContext(ctx).logContext(ctx, LevelError, 2, message, keyvals)
+}
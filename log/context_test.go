@@ -0,0 +1,75 @@
+/**
+@file          context_test.go
+@package       log
+@brief         Test context aware logging.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+type tenantKey struct{}
+
+func TestContextLogging(t *testing.T) {
+	logger, ring := newTestRingLogger(t)
+	if FromContext(context.Background()) != defaultLogger {
+		t.Errorf("Expected the default logger.")
+	}
+	ctx := WithContext(context.Background(), logger.With("service", "api"))
+	ctx = ContextWithTraceID(ctx, "4bf92f3577b34da6")
+	ctx = ContextWithSpanID(ctx, "00f067aa0ba902b7")
+	ctx = ContextWithRequestID(ctx, "r-1")
+	remove := AddContextExtractor(func(ctx context.Context) []Field {
+		if tenant, ok := ctx.Value(tenantKey{}).(string); ok {
+			return []Field{{Key: "tenant", Value: tenant}}
+		}
+		return nil
+	})
+	t.Cleanup(remove)
+	ctx = context.WithValue(ctx, tenantKey{}, "acme")
+
+	InfoContext(ctx, "Handled.", "status", 200)
+	logger.DebugContext(context.Background(), "No IDs.")
+	slog.New(NewSlogHandler(logger)).WarnContext(ctx, "Slow.", "ms", 900)
+
+	var lines []string
+	for _, line := range ring.Lines(RingQuery{}) {
+		lines = append(lines, strings.TrimSpace(string(line)))
+	}
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines, found %d.", len(lines))
+	}
+	r := "Handled. service=api trace_id=4bf92f3577b34da6 span_id=00f067aa0ba902b7 request_id=r-1 tenant=acme status=200"
+	if !strings.HasSuffix(lines[0], r) || !strings.Contains(lines[0], "log/context_test.go:") {
+		t.Errorf("Expected '%s' in '%s'.", r, lines[0])
+	}
+	if !strings.HasSuffix(lines[1], "Debug: No IDs.") {
+		t.Errorf("Unexpected line '%s'.", lines[1])
+	}
+	if !strings.Contains(lines[2], "trace_id=4bf92f3577b34da6") {
+		t.Errorf("Unexpected line '%s'.", lines[2])
+	}
+
+	entry := ring.Entries(RingQuery{})[0]
+	json := JSONEncoder{}.Encode(entry)
+	if !bytes.Contains(json, []byte(`"trace_id":"4bf92f3577b34da6","span_id":"00f067aa0ba902b7","request_id":"r-1"`)) {
+		t.Errorf("Unexpected JSON '%s'.", json)
+	}
+	if TraceIDFromContext(ctx) != "4bf92f3577b34da6" || SpanIDFromContext(ctx) != "00f067aa0ba902b7" ||
+		RequestIDFromContext(ctx) != "r-1" {
+		t.Errorf("Unexpected IDs.")
+	}
+	remove()
+	if fields := contextFields(ctx); len(fields) != 3 {
+		t.Errorf("Expected the tenant extractor to be removed, found %v.", fields)
+	}
+}
@@ -0,0 +1,170 @@
+/**
+@file          control.go
+@package       log
+@brief         An HTTP handler that shows and changes the log settings at runtime.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Config is the log configuration returned by the control handler.
+type Config struct {
+	Level             string   `json:"level"`
+	LevelOverrides    string   `json:"levelOverrides"`
+	Filename          string   `json:"filename"`
+	TeeStderr         bool     `json:"teeStderr"`
+	RotationInterval  string   `json:"rotationInterval"`
+	MaxFileSize       int64    `json:"maxFileSize"`
+	CompressRotated   bool     `json:"compressRotated"`
+	RetentionCount    int      `json:"retentionCount"`
+	RetentionMaxAge   string   `json:"retentionMaxAge"`
+	RetentionMaxBytes int64    `json:"retentionMaxBytes"`
+	Async             bool     `json:"async"`
+	DroppedMessages   uint64   `json:"droppedMessages"`
+	Sinks             []string `json:"sinks"`
+}
+
+// Config returns the logger's current configuration.
+func (logger *Logger) Config() Config {
+	config := Config{
+		Level:             ShortStringFromLevel(logger.LogLevel()),
+		LevelOverrides:    logger.LevelOverrides(),
+		Filename:          logger.Filename(),
+		TeeStderr:         logger.TeeStderr(),
+		RotationInterval:  logger.RotationInterval().String(),
+		MaxFileSize:       logger.MaxFileSize(),
+		CompressRotated:   logger.CompressRotated(),
+		RetentionCount:    logger.RetentionCount(),
+		RetentionMaxAge:   logger.RetentionMaxAge().String(),
+		RetentionMaxBytes: logger.RetentionMaxBytes(),
+		Async:             logger.Async(),
+		DroppedMessages:   logger.DroppedMessages(),
+		Sinks:             []string{},
+	}
+	for _, sink := range logger.Sinks() {
+		config.Sinks = append(config.Sinks, fmt.Sprintf("%T", sink))
+	}
+	return config
+}
+
+// Rotate rotates the log file now, whether or not it's due. It returns the name of the rotated
+// file, or the empty string if there's no log file.
+func (logger *Logger) Rotate() string {
+	logger.drainQueue()
+	logger.writeMutex.Lock()
+	rotatedPath := logger.rotateLogFile()
+	logger.writeMutex.Unlock()
+	logger.archiveRotatedLogFile(rotatedPath)
+	return rotatedPath
+}
+
+// Rotate rotates the default Logger's log file now.
+func Rotate() string { return defaultLogger.Rotate() }
+
+// ControlHandler returns an http.Handler for an admin server that shows and changes the log
+// settings without a restart. A GET returns the configuration as JSON. A POST or PUT changes the
+// settings given as form or query values and then returns the new configuration:
+//
+//	level=debug                 Sets the log level.
+//	overrides=scanner=debug     Sets the level overrides. See SetLevelOverrides.
+//	tee=true                    Sets TeeStderr.
+//	rotate=true                 Rotates the log file.
+//	flush=true                  Flushes the log and its sinks.
+func (logger *Logger) ControlHandler() http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch request.Method {
+		case http.MethodGet, http.MethodHead:
+		case http.MethodPost, http.MethodPut:
+			if error := logger.applyControlRequest(request); error != nil {
+				http.Error(writer, error.Error(), http.StatusBadRequest)
+				return
+			}
+		default:
+			writer.Header().Set("Allow", "GET, HEAD, POST, PUT")
+			http.Error(writer, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Header().Set("Cache-Control", "no-store")
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(logger.Config())
+	})
+}
+
+// ControlHandler returns an http.Handler that shows and changes the default Logger's settings.
+func ControlHandler() http.Handler { return defaultLogger.ControlHandler() }
+
+// applyControlRequest checks all the values in the request and then applies them.
+func (logger *Logger) applyControlRequest(request *http.Request) error {
+	if error := request.ParseForm(); error != nil {
+		return error
+	}
+	var changes []func()
+	parseBool := func(name string) (bool, error) {
+		value, error := strconv.ParseBool(request.Form.Get(name))
+		if error != nil {
+			return false, fmt.Errorf("invalid %s value '%s'", name, request.Form.Get(name))
+		}
+		return value, nil
+	}
+
+	if request.Form.Has("level") {
+		level := LevelFromString(request.Form.Get("level"))
+		if level == LevelInvalid {
+			return fmt.Errorf("invalid level '%s'", request.Form.Get("level"))
+		}
+		changes = append(changes, func() {
+			logger.SetLogLevel(level)
+			logger.Infof("Log level set to %s.", StringFromLevel(level))
+		})
+	}
+	if request.Form.Has("overrides") {
+		spec := request.Form.Get("overrides")
+		if _, error := parseLevelOverrides(spec); error != nil {
+			return error
+		}
+		changes = append(changes, func() {
+			logger.SetLevelOverrides(spec)
+			logger.Infof("Log level overrides set to '%s'.", spec)
+		})
+	}
+	if request.Form.Has("tee") {
+		tee, error := parseBool("tee")
+		if error != nil {
+			return error
+		}
+		changes = append(changes, func() { logger.SetTeeStderr(tee) })
+	}
+	if request.Form.Has("rotate") {
+		rotate, error := parseBool("rotate")
+		if error != nil {
+			return error
+		}
+		if rotate {
+			changes = append(changes, func() { logger.Rotate() })
+		}
+	}
+	if request.Form.Has("flush") {
+		flush, error := parseBool("flush")
+		if error != nil {
+			return error
+		}
+		if flush {
+			changes = append(changes, logger.FlushMessages)
+		}
+	}
+	for _, change := range changes {
+		change()
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+/**
+@file          control_test.go
+@package       log
+@brief         Test the runtime log control handler.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestControlHandler(t *testing.T) {
+	dir := t.TempDir()
+	logger := NewLogger()
+	logger.SetFilename(filepath.Join(dir, "control.log"))
+	defer logger.SetFilename("")
+	handler := logger.ControlHandler()
+
+	request := func(method, body string) (*httptest.ResponseRecorder, Config) {
+		r := httptest.NewRequest(method, "/log", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		var config Config
+		if w.Code == http.StatusOK {
+			if error := json.Unmarshal(w.Body.Bytes(), &config); error != nil {
+				t.Fatal(error)
+			}
+		}
+		return w, config
+	}
+
+	_, config := request(http.MethodGet, "")
+	if config.Level != "info" || config.Filename != logger.Filename() || config.RotationInterval != "24h0m0s" {
+		t.Errorf("Unexpected config %+v.", config)
+	}
+
+	form := url.Values{
+		"level":     {"debug"},
+		"overrides": {"scanner=warn"},
+		"tee":       {"false"},
+		"rotate":    {"true"},
+		"flush":     {"true"},
+	}
+	w, config := request(http.MethodPost, form.Encode())
+	if w.Code != http.StatusOK {
+		t.Fatalf("Unexpected status %d: %s", w.Code, w.Body.String())
+	}
+	if config.Level != "debug" || config.LevelOverrides != "scanner=warning" || logger.LogLevel() != LevelDebug {
+		t.Errorf("Unexpected config %+v.", config)
+	}
+	logger.archiving.Wait()
+	if files, _ := filepath.Glob(filepath.Join(dir, "control-*.log")); len(files) != 1 {
+		t.Errorf("Expected a rotated log file, found %v.", files)
+	}
+
+	if w, _ := request(http.MethodPost, "level=loud&tee=true"); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected a bad request, found %d.", w.Code)
+	}
+	if logger.TeeStderr() {
+		t.Errorf("An invalid request shouldn't change the settings.")
+	}
+	if w, _ := request(http.MethodDelete, ""); w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected method not allowed, found %d.", w.Code)
+	}
+}
@@ -0,0 +1,136 @@
+/**
+@file          encoder.go
+@package       log
+@brief         Encoders that format log entries as lines of text or JSON.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Encoder formats a log entry as a single line of output, including the trailing newline.
+type Encoder interface {
+	Encode(entry *Entry) []byte
+}
+
+// levelShortNames are the lowercase level names used by machine readable encoders.
+var levelShortNames = []string{
+	"invalid",
+	"all",
+	"debug",
+	"info",
+	"start",
+	"exit",
+	"warning",
+	"error",
+	"none",
+}
+
+// ShortStringFromLevel returns the short lowercase name of the level, such as "debug" or "warning".
+func ShortStringFromLevel(level Level) string {
+	if level < LevelInvalid || level > LevelNone {
+		return levelShortNames[LevelInvalid]
+	}
+	return levelShortNames[level]
+}
+
+// TextEncoder encodes entries in the standard gokit log line format:
+//
+//	2006-01-02T15:04:05-07:00       dir/file.go:123  Info: Message key=value
+type TextEncoder struct{}
+
+// Encode formats an entry as a line of text.
+func (TextEncoder) Encode(entry *Entry) []byte {
+	LevelNames := []string{
+		"Inval",
+		"  All",
+		"Debug",
+		" Info",
+		"Start",
+		" Exit",
+		" Warn",
+		"Error",
+		" None",
+	}
+
+	var message = entry.Message
+	if len(entry.Fields) > 0 {
+		message += " " + formatFields(entry.Fields)
+	}
+	message = strings.Replace(message, "\n", "|", -1)
+	message = strings.Replace(message, "\r", "|", -1)
+	return []byte(fmt.Sprintf(
+		"%s %26s:%-4d %s: %s\n",
+		entry.Time.Format(time.RFC3339),
+		entry.Caller(),
+		entry.Line,
+		LevelNames[entry.Level],
+		message,
+	))
+}
+
+// JSONEncoder encodes entries as one JSON object per line with `time`, `level`, `caller`, and `msg`
+// keys followed by the entry's fields.
+type JSONEncoder struct{}
+
+// Encode formats an entry as a line of JSON.
+func (JSONEncoder) Encode(entry *Entry) []byte {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{"time":`)
+	writeJSONValue(&buffer, entry.Time.Format(time.RFC3339Nano))
+	buffer.WriteString(`,"level":`)
+	writeJSONValue(&buffer, ShortStringFromLevel(entry.Level))
+	buffer.WriteString(`,"caller":`)
+	writeJSONValue(&buffer, entry.Caller()+":"+strconv.Itoa(entry.Line))
+	buffer.WriteString(`,"msg":`)
+	writeJSONValue(&buffer, entry.Message)
+	for _, field := range entry.Fields {
+		buffer.WriteByte(',')
+		writeJSONValue(&buffer, field.Key)
+		buffer.WriteByte(':')
+		writeJSONValue(&buffer, field.Value)
+	}
+	buffer.WriteString("}\n")
+	return buffer.Bytes()
+}
+
+// messageLineReplacer replaces the line breaks in messages written by the MessageEncoder.
+var messageLineReplacer = strings.NewReplacer("\r\n", "|", "\n", "|", "\r", "|")
+
+// MessageEncoder encodes only the message of each entry, for logs such as access logs whose
+// messages are already formatted lines.
+type MessageEncoder struct{}
+
+// Encode returns the entry's message as a line.
+func (MessageEncoder) Encode(entry *Entry) []byte {
+	message := messageLineReplacer.Replace(entry.Message)
+	return []byte(message + "\n")
+}
+
+// writeJSONValue writes the value as JSON. Values that can't be marshaled are written as strings.
+func writeJSONValue(buffer *bytes.Buffer, value interface{}) {
+	switch v := value.(type) {
+	case error:
+		value = v.Error()
+	case time.Duration:
+		value = v.String()
+	case json.Marshaler:
+	case fmt.Stringer:
+		value = v.String()
+	}
+	b, error := json.Marshal(value)
+	if error != nil {
+		b, _ = json.Marshal(fmt.Sprintf("%+v", value))
+	}
+	buffer.Write(b)
+}
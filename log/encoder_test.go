@@ -0,0 +1,70 @@
+/**
+@file          encoder_test.go
+@package       log
+@brief         Test the text and JSON log encoders.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func testEntry() *Entry {
+	return &Entry{
+		Time:    time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC),
+		Level:   LevelWarning,
+		File:    "/src/gokit/scanner/scanner.go",
+		Line:    42,
+		Message: "Two\nlines.",
+		Fields: []Field{
+			{Key: "user", Value: 7},
+			{Key: "err", Value: errors.New("failed")},
+		},
+	}
+}
+
+func TestTextEncoder(t *testing.T) {
+	r := "2026-10-17T09:30:00Z         scanner/scanner.go:42    Warn: Two|lines. user=7 err=failed\n"
+	if s := string(TextEncoder{}.Encode(testEntry())); s != r {
+		t.Errorf("Expected\n%s\nbut found\n%s", r, s)
+	}
+}
+
+func TestMessageEncoder(t *testing.T) {
+	entry := testEntry()
+	entry.Message = "One\r\ntwo\rthree\nfour."
+	if s := string(MessageEncoder{}.Encode(entry)); s != "One|two|three|four.\n" {
+		t.Errorf("Unexpected line %q.", s)
+	}
+}
+
+func TestJSONEncoder(t *testing.T) {
+	b := JSONEncoder{}.Encode(testEntry())
+	if b[len(b)-1] != '\n' {
+		t.Errorf("Expected a trailing newline.")
+	}
+	var m map[string]interface{}
+	if error := json.Unmarshal(b, &m); error != nil {
+		t.Fatalf("Can't unmarshal '%s': %v.", b, error)
+	}
+	r := map[string]interface{}{
+		"time":   "2026-10-17T09:30:00Z",
+		"level":  "warning",
+		"caller": "scanner/scanner.go:42",
+		"msg":    "Two\nlines.",
+		"user":   7.0,
+		"err":    "failed",
+	}
+	for key, value := range r {
+		if m[key] != value {
+			t.Errorf("Expected %s=%v but found %v.", key, value, m[key])
+		}
+	}
+}
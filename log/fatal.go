@@ -0,0 +1,154 @@
+/**
+@file          fatal.go
+@package       log
+@brief         Fatal and panic messages that flush the log and run shutdown hooks first.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// exit ends the process after a fatal message. It's replaced by tests.
+var exit = os.Exit
+
+var shutdownHooks = struct {
+	sync.Mutex
+	hooks []func()
+}{}
+
+// AddShutdownHook registers a function that's run by Shutdown, Fatalf, and Panicf before the
+// process ends. Hooks are run once, most recently added first. Messages the hooks log are flushed.
+func AddShutdownHook(hook func()) {
+	shutdownHooks.Lock()
+	defer shutdownHooks.Unlock()
+	shutdownHooks.hooks = append(shutdownHooks.hooks, hook)
+}
+
+// runShutdownHooks runs and removes the registered shutdown hooks.
+func runShutdownHooks() {
+	shutdownHooks.Lock()
+	hooks := shutdownHooks.hooks
+	shutdownHooks.hooks = nil
+	shutdownHooks.Unlock()
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hooks[i]()
+	}
+}
+
+// flushedLoggers are the loggers with asynchronous queues, sinks, or archives being compressed that
+// are flushed by Shutdown, Fatalf, and Panicf, whichever logger they're called on.
+var flushedLoggers = struct {
+	sync.Mutex
+	cores map[*loggerCore]bool
+}{}
+
+// updateFlush adds the logger to the loggers that are flushed when the process ends if it's
+// asynchronous, has sinks, or is archiving a rotated file, and otherwise removes it so that it can
+// be garbage collected. It's called after each of those changes, and must not be called while the
+// logger's mutex or asyncMutex is held.
+func (logger *Logger) updateFlush() {
+	flushedLoggers.Lock()
+	defer flushedLoggers.Unlock()
+	if !logger.Async() && len(logger.Sinks()) == 0 && !logger.archiving.Running() {
+		delete(flushedLoggers.cores, logger.loggerCore)
+		return
+	}
+	if flushedLoggers.cores == nil {
+		flushedLoggers.cores = make(map[*loggerCore]bool)
+	}
+	flushedLoggers.cores[logger.loggerCore] = true
+}
+
+// flushAll writes the queued messages and flushes the sinks of the logger, the default Logger, and
+// every logger that's asynchronous, has sinks, or is compressing an archive, and waits for rotated
+// log files to be archived.
+func (logger *Logger) flushAll() {
+	cores := []*loggerCore{logger.loggerCore, defaultLogger.loggerCore}
+	flushedLoggers.Lock()
+	for core := range flushedLoggers.cores {
+		if core != logger.loggerCore && core != defaultLogger.loggerCore {
+			cores = append(cores, core)
+		}
+	}
+	flushedLoggers.Unlock()
+	for i, core := range cores {
+		if i > 0 && core == cores[0] {
+			continue
+		}
+		flushed := &Logger{loggerCore: core}
+		flushed.FlushMessages()
+		flushed.archiving.Wait()
+	}
+}
+
+// Shutdown flushes the logger and the other loggers, runs the shutdown hooks, and flushes again so
+// that nothing logged by the hooks is lost. Call it before the process exits normally.
+func (logger *Logger) Shutdown() {
+	logger.flushAll()
+	runShutdownHooks()
+	logger.flushAll()
+}
+
+// Shutdown flushes the default Logger and runs the shutdown hooks.
+func Shutdown() { defaultLogger.Shutdown() }
+
+// terminate writes the message and stack at the error level, whatever the log level, and shuts
+// down. The queued messages are written first, and the message and stack are written directly
+// so that a full asynchronous queue can't drop them. stackDepth is counted as runtime.Caller
+// counts it.
+func (logger *Logger) terminate(stackDepth int, message string) {
+	now := time.Now()
+	entry := &Entry{Time: now, Level: LevelError, Message: message, Fields: logger.fields}
+	entry.PC, entry.File, entry.Line, _ = runtime.Caller(stackDepth)
+	stack := *entry
+	stack.Message = "Stack:\n" + strings.TrimSuffix(PrettyStackString(stackDepth+1), "\n")
+	logger.flushRepeats()
+	logger.drainQueue()
+	for _, entry := range []*Entry{entry, &stack} {
+		if redactor := logger.Redactor(); redactor != nil {
+			entry = redactor.RedactEntry(entry)
+		}
+		logger.archiveRotatedLogFile(logger.writeEncoded(entry, logger.Encoder().Encode(entry)))
+	}
+	logger.Shutdown()
+}
+
+// Fatalf writes an error message and the stack to the log, flushes the log, its sinks, and the
+// other loggers, runs the shutdown hooks, and exits the process with status 1.
+func (logger *Logger) Fatalf(format string, args ...interface{}) {
+	logger.terminate(2, fmt.Sprintf(format, args...))
+	exit(1)
+}
+
+// Panicf writes an error message and the stack to the log, flushes the log, its sinks, and the
+// other loggers, runs the shutdown hooks, and panics with the message.
+func (logger *Logger) Panicf(format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	logger.terminate(2, message)
+	panic(message)
+}
+
+// Fatalf writes an error message and the stack to the default Logger, flushes it, runs the
+// shutdown hooks, and exits the process with status 1.
+func Fatalf(format string, args ...interface{}) {
+	defaultLogger.terminate(2, fmt.Sprintf(format, args...))
+	exit(1)
+}
+
+// Panicf writes an error message and the stack to the default Logger, flushes it, runs the
+// shutdown hooks, and panics with the message.
+func Panicf(format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	defaultLogger.terminate(2, message)
+	panic(message)
+}
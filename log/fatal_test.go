@@ -0,0 +1,144 @@
+/**
+@file          fatal_test.go
+@package       log
+@brief         Test fatal and panic messages.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFatalf(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "fatal.log")
+	logger := NewLogger()
+	logger.SetFilename(filename)
+	defer logger.SetFilename("")
+	logger.SetLogLevel(LevelNone)
+	logger.SetAsync(100, OverflowBlock)
+	defer logger.SetAsync(0, OverflowBlock)
+
+	var order []string
+	AddShutdownHook(func() { order = append(order, "first") })
+	AddShutdownHook(func() {
+		order = append(order, "second")
+		logger.Errorf("Hook ran.")
+	})
+	status := -1
+	exit = func(code int) { status = code }
+	defer func() { exit = os.Exit }()
+
+	logger.SetLogLevel(LevelInfo)
+	logger.Infof("Before.")
+	logger.SetLogLevel(LevelNone)
+	logger.Fatalf("Can't open %s.", "database")
+
+	if status != 1 || strings.Join(order, " ") != "second first" {
+		t.Errorf("Unexpected status %d and hooks %v.", status, order)
+	}
+	b, _ := os.ReadFile(filename)
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines, found:\n%s", b)
+	}
+	if !strings.Contains(lines[1], "log/fatal_test.go:") || !strings.HasSuffix(lines[1], "Error: Can't open database.") {
+		t.Errorf("Unexpected line '%s'.", lines[1])
+	}
+	if !strings.Contains(lines[2], "Error: Stack:|fatal_test.go:") {
+		t.Errorf("Unexpected stack '%s'.", lines[2])
+	}
+}
+
+func TestPanicf(t *testing.T) {
+	logger, ring := newTestRingLogger(t)
+	ran := false
+	AddShutdownHook(func() { ran = true })
+	defer func() {
+		reason := recover()
+		if reason != "Corrupt index 7." || !ran {
+			t.Errorf("Unexpected panic %v, hook ran %v.", reason, ran)
+		}
+		if entries := ring.Entries(RingQuery{}); len(entries) != 2 || entries[0].Message != "Corrupt index 7." {
+			t.Errorf("Unexpected entries %+v.", entries)
+		}
+	}()
+	logger.Panicf("Corrupt index %d.", 7)
+}
+
+func TestFlushedLoggers(t *testing.T) {
+	logger := NewLogger()
+	flushed := func() bool {
+		flushedLoggers.Lock()
+		defer flushedLoggers.Unlock()
+		return flushedLoggers.cores[logger.loggerCore]
+	}
+	sink := NewRingSink(1)
+	logger.AddSink(sink)
+	logger.SetAsync(10, OverflowBlock)
+	logger.RemoveSink(sink)
+	if !flushed() {
+		t.Errorf("Expected an asynchronous logger to be flushed.")
+	}
+	logger.SetAsync(0, OverflowBlock)
+	if flushed() {
+		t.Errorf("Expected the logger to be removed without a queue or sinks.")
+	}
+	logger.AddSink(sink)
+	if !flushed() {
+		t.Errorf("Expected a logger with a sink to be flushed.")
+	}
+	logger.RemoveSink(sink)
+	if flushed() {
+		t.Errorf("Expected the logger to be removed after its last sink.")
+	}
+}
+
+func TestFatalfFullQueue(t *testing.T) {
+	dir := t.TempDir()
+	logger := NewLogger()
+	logger.SetFilename(filepath.Join(dir, "fatal.log"))
+	defer logger.SetFilename("")
+	logger.SetAsync(2, OverflowDropNewest)
+	defer logger.SetAsync(0, OverflowBlock)
+
+	other := NewLogger()
+	other.SetFilename(filepath.Join(dir, "other.log"))
+	defer other.SetFilename("")
+	other.SetAsync(100, OverflowBlock)
+	defer other.SetAsync(0, OverflowBlock)
+
+	exit = func(code int) {}
+	defer func() { exit = os.Exit }()
+
+	//  Stall both writers so that the queues are full and unwritten when Fatalf is called --
+
+	logger.writeMutex.Lock()
+	other.writeMutex.Lock()
+	for i := 0; i < 10; i++ {
+		logger.Infof("Message %d.", i)
+	}
+	other.Infof("Queued.")
+	go func() {
+		time.Sleep(time.Millisecond * 50)
+		logger.writeMutex.Unlock()
+		other.writeMutex.Unlock()
+	}()
+	logger.Fatalf("Out of disk.")
+
+	b, _ := os.ReadFile(filepath.Join(dir, "fatal.log"))
+	if !strings.Contains(string(b), "Error: Out of disk.") || !strings.Contains(string(b), "Error: Stack:|") {
+		t.Errorf("Expected the fatal message and stack, found:\n%s", b)
+	}
+	b, _ = os.ReadFile(filepath.Join(dir, "other.log"))
+	if !strings.Contains(string(b), "Info: Queued.") {
+		t.Errorf("Expected the other logger to be flushed, found:\n%s", b)
+	}
+}
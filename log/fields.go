@@ -0,0 +1,114 @@
+/**
+@file          fields.go
+@package       log
+@brief         Structured key / value fields for log messages.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Field is a key / value pair attached to a log message.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// badKey is the key used for a value that is missing its key.
+const badKey = "!BADKEY"
+
+// Entry is a single log message with its context.
+type Entry struct {
+	Time    time.Time
+	Level   Level
+	PC      uintptr
+	File    string
+	Line    int
+	Message string
+	Fields  []Field
+}
+
+// Caller returns the directory and file name of the source file that wrote the entry, as it's shown in the log.
+func (entry *Entry) Caller() string {
+	dirname, filename := path.Split(entry.File)
+	dirname = path.Base(dirname)
+	i := len(filename)
+	if i > 26 {
+		i = 26
+	}
+	return dirname + "/" + filename[:i]
+}
+
+// appendFields returns a new slice of the fields followed by the fields made from the
+// alternating keys and values in keyvals. A Field in keyvals is added as is.
+func appendFields(fields []Field, keyvals []interface{}) []Field {
+	if len(keyvals) == 0 {
+		return fields
+	}
+	result := make([]Field, len(fields), len(fields)+(len(keyvals)+1)/2)
+	copy(result, fields)
+	for i := 0; i < len(keyvals); i++ {
+		switch key := keyvals[i].(type) {
+		case Field:
+			result = append(result, key)
+		case string:
+			if i+1 < len(keyvals) {
+				result = append(result, Field{Key: key, Value: keyvals[i+1]})
+				i++
+			} else {
+				result = append(result, Field{Key: badKey, Value: key})
+			}
+		default:
+			result = append(result, Field{Key: badKey, Value: key})
+		}
+	}
+	return result
+}
+
+// fieldValueString returns the field value as a string.
+func fieldValueString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case error:
+		return v.Error()
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprintf("%+v", v)
+	}
+}
+
+// formatFieldValue returns the field value as a string, quoted if needed so that it's unambiguous in a line of text.
+func formatFieldValue(value interface{}) string {
+	s := fieldValueString(value)
+	if s == "" || strings.ContainsAny(s, " =\"\t\r\n") {
+		s = strconv.Quote(s)
+	}
+	return s
+}
+
+// formatFields formats the fields as space separated key=value pairs.
+func formatFields(fields []Field) string {
+	var builder strings.Builder
+	for i, field := range fields {
+		if i > 0 {
+			builder.WriteByte(' ')
+		}
+		builder.WriteString(field.Key)
+		builder.WriteByte('=')
+		builder.WriteString(formatFieldValue(field.Value))
+	}
+	return builder.String()
+}
@@ -0,0 +1,75 @@
+/**
+@file          fields_test.go
+@package       log
+@brief         Test structured log fields.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatFields(t *testing.T) {
+	fields := appendFields(nil, []interface{}{
+		"user", 42,
+		"name", "Jane Doe",
+		"err", errors.New("bad"),
+		"latency", time.Millisecond * 1500,
+		Field{Key: "shard", Value: "a"},
+		"dangling",
+	})
+	r := `user=42 name="Jane Doe" err=bad latency=1.5s shard=a !BADKEY=dangling`
+	if s := formatFields(fields); s != r {
+		t.Errorf("Expected\n%s\nbut found\n%s", r, s)
+	}
+}
+
+func TestWithFields(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "fields.log")
+	logger := NewLogger()
+	logger.SetFilename(filename)
+
+	child := logger.With("request", "r1")
+	child.Info("Handled.", "status", 200)
+	logger.Info("Parent.")
+	logger.SetFilename("")
+
+	if len(child.Fields()) != 1 || len(logger.Fields()) != 0 {
+		t.Errorf("Unexpected fields %v %v.", child.Fields(), logger.Fields())
+	}
+	b, _ := os.ReadFile(filename)
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, found:\n%s", b)
+	}
+	if !strings.HasSuffix(lines[0], " Info: Handled. request=r1 status=200") {
+		t.Errorf("Unexpected line '%s'.", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], " Info: Parent.") {
+		t.Errorf("Unexpected line '%s'.", lines[1])
+	}
+}
+
+func TestErrorMessage(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "error.log")
+	logger := NewLogger()
+	logger.SetFilename(filename)
+
+	logger.With("request", "r1").ErrorMessage("Can't write shard.", "shard", 7)
+	logger.SetFilename("")
+
+	b, _ := os.ReadFile(filename)
+	line := strings.TrimSpace(string(b))
+	if !strings.HasSuffix(line, "Error: Can't write shard. request=r1 shard=7") || !strings.Contains(line, "log/fields_test.go:") {
+		t.Errorf("Unexpected line '%s'.", line)
+	}
+}
@@ -0,0 +1,153 @@
+/**
+@file          journald.go
+@package       log
+@brief         A sink that writes to systemd-journald with its native protocol.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultJournaldSocket is the path of journald's native protocol socket.
+const DefaultJournaldSocket = "/run/systemd/journal/socket"
+
+// JournaldSink is a Sink that writes log entries to systemd-journald using its native protocol, so
+// the level, caller, and fields are kept as separate journal fields. Entries are sent as single
+// datagrams, so very large entries may be rejected by the socket.
+type JournaldSink struct {
+	identifier string
+
+	mutex sync.Mutex
+	conn  *net.UnixConn
+	addr  *net.UnixAddr
+}
+
+// NewJournaldSink returns a sink that writes to the journald socket at socketPath. If socketPath is
+// empty DefaultJournaldSocket is used. The identifier is the SYSLOG_IDENTIFIER of each entry and
+// defaults to the program name.
+func NewJournaldSink(socketPath, identifier string) (*JournaldSink, error) {
+	if socketPath == "" {
+		socketPath = DefaultJournaldSocket
+	}
+	if identifier == "" {
+		identifier = filepath.Base(os.Args[0])
+	}
+	conn, error := net.ListenUnixgram("unixgram", &net.UnixAddr{Net: "unixgram"})
+	if error != nil {
+		return nil, error
+	}
+	sink := &JournaldSink{
+		identifier: identifier,
+		conn:       conn,
+		addr:       &net.UnixAddr{Name: socketPath, Net: "unixgram"},
+	}
+	return sink, nil
+}
+
+// journalSinkFields are the fields the JournaldSink writes itself. Entry fields with these names
+// are prefixed with GOKIT_ so that they can't replace or duplicate them.
+var journalSinkFields = map[string]bool{
+	"MESSAGE":           true,
+	"PRIORITY":          true,
+	"SYSLOG_IDENTIFIER": true,
+	"GOKIT_LEVEL":       true,
+	"CODE_FILE":         true,
+	"CODE_LINE":         true,
+	"CODE_FUNC":         true,
+}
+
+// journalFieldName returns the key as a valid journal field name: uppercase letters, digits, and
+// underscores, not starting with an underscore or digit. Names the sink writes itself are
+// prefixed with GOKIT_.
+func journalFieldName(key string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_':
+			return r
+		}
+		return '_'
+	}, key)
+	name = strings.TrimLeft(name, "_0123456789")
+	if journalSinkFields[name] {
+		name = "GOKIT_" + name
+	}
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return name
+}
+
+// appendJournalField appends a field in journald's native format. Values with a newline are
+// written with their length instead of being newline terminated.
+func appendJournalField(buffer *bytes.Buffer, name, value string) {
+	if name == "" {
+		return
+	}
+	buffer.WriteString(name)
+	if !strings.Contains(value, "\n") {
+		buffer.WriteByte('=')
+		buffer.WriteString(value)
+		buffer.WriteByte('\n')
+		return
+	}
+	buffer.WriteByte('\n')
+	binary.Write(buffer, binary.LittleEndian, uint64(len(value)))
+	buffer.WriteString(value)
+	buffer.WriteByte('\n')
+}
+
+// Format returns the entry in journald's native format.
+func (sink *JournaldSink) Format(entry *Entry) []byte {
+	var buffer bytes.Buffer
+	appendJournalField(&buffer, "MESSAGE", entry.Message)
+	appendJournalField(&buffer, "PRIORITY", strconv.Itoa(SyslogSeverityFromLevel(entry.Level)))
+	appendJournalField(&buffer, "SYSLOG_IDENTIFIER", sink.identifier)
+	appendJournalField(&buffer, "GOKIT_LEVEL", ShortStringFromLevel(entry.Level))
+	if entry.File != "" {
+		appendJournalField(&buffer, "CODE_FILE", entry.File)
+		appendJournalField(&buffer, "CODE_LINE", strconv.Itoa(entry.Line))
+	}
+	if function := runtime.FuncForPC(entry.PC); function != nil {
+		appendJournalField(&buffer, "CODE_FUNC", function.Name())
+	}
+	for _, field := range entry.Fields {
+		appendJournalField(&buffer, journalFieldName(field.Key), fieldValueString(field.Value))
+	}
+	return buffer.Bytes()
+}
+
+// WriteEntry writes the entry to journald.
+func (sink *JournaldSink) WriteEntry(entry *Entry, _ []byte) error {
+	message := sink.Format(entry)
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	_, error := sink.conn.WriteToUnix(message, sink.addr)
+	return error
+}
+
+// Flush does nothing since entries are written as they're received.
+func (sink *JournaldSink) Flush() error {
+	return nil
+}
+
+// Close closes the journald socket.
+func (sink *JournaldSink) Close() error {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	return sink.conn.Close()
+}
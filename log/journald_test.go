@@ -0,0 +1,74 @@
+/**
+@file          journald_test.go
+@package       log
+@brief         Test the journald sink against a local socket.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"encoding/binary"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJournaldSink(t *testing.T) {
+	path := filepath.Join(shortTempDir(t), "journal")
+	conn, error := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if error != nil {
+		t.Fatal(error)
+	}
+	defer conn.Close()
+
+	sink, error := NewJournaldSink(path, "test")
+	if error != nil {
+		t.Fatal(error)
+	}
+	defer sink.Close()
+	entry := testEntry()
+	entry.Level = LevelInfo
+	entry.Message = "Hello, world."
+	entry.Fields = []Field{{"request-id", 7}, {"_trusted", "x"}, {"stack", "a\nb"}, {"message", "m"}, {"code-line", 1}}
+	if error := sink.WriteEntry(entry, nil); error != nil {
+		t.Fatal(error)
+	}
+
+	buffer := make([]byte, 4096)
+	conn.SetReadDeadline(time.Now().Add(time.Second * 5))
+	n, error := conn.Read(buffer)
+	if error != nil {
+		t.Fatal(error)
+	}
+	data := string(buffer[:n])
+	for _, field := range []string{
+		"MESSAGE=Hello, world.\n",
+		"PRIORITY=6\n",
+		"SYSLOG_IDENTIFIER=test\n",
+		"CODE_LINE=42\n",
+		"REQUEST_ID=7\n",
+		"TRUSTED=x\n",
+		"GOKIT_MESSAGE=m\n",
+		"GOKIT_CODE_LINE=1\n",
+	} {
+		if !strings.Contains(data, field) {
+			t.Errorf("Expected '%s' in '%s'.", strings.TrimSpace(field), data)
+		}
+	}
+	if n := strings.Count(data, "\nMESSAGE=") + strings.Count(data, "\nCODE_LINE="); !strings.HasPrefix(data, "MESSAGE=") || n != 1 {
+		t.Errorf("Expected one MESSAGE and one CODE_LINE field in '%s'.", data)
+	}
+	i := strings.Index(data, "STACK\n")
+	if i < 0 {
+		t.Fatalf("Expected a binary STACK field in '%s'.", data)
+	}
+	value := data[i+len("STACK\n"):]
+	if size := binary.LittleEndian.Uint64([]byte(value[:8])); size != 3 || value[8:12] != "a\nb\n" {
+		t.Errorf("Unexpected STACK value %q.", value)
+	}
+}
@@ -0,0 +1,215 @@
+/**
+@file          levels.go
+@package       log
+@brief         Per-package log level overrides.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"fmt"
+	"path"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// levelFromShortName returns the level for a case insensitive level name, short name, or
+// common abbreviation such as "warn".
+func levelFromShortName(s string) Level {
+	s = strings.TrimSpace(s)
+	for index := range levelNames {
+		if strings.EqualFold(s, levelNames[index]) || strings.EqualFold(s, levelShortNames[index]) {
+			return Level(index)
+		}
+	}
+	switch strings.ToLower(s) {
+	case "warn":
+		return LevelWarning
+	case "err":
+		return LevelError
+	}
+	return LevelInvalid
+}
+
+// levelOverride sets the log level for the packages or source files that match the pattern.
+type levelOverride struct {
+	pattern string
+	level   Level
+}
+
+// levelOverrides are a logger's level overrides with the level decided for each caller.
+// They're replaced as a whole when they change, which also clears the cache.
+type levelOverrides struct {
+	overrides []levelOverride
+	minLevel  Level
+
+	// The override level for each caller PC, or LevelInvalid if no pattern matches.
+	cache sync.Map
+}
+
+// parseLevelOverrides parses a comma separated list of pattern=level overrides.
+func parseLevelOverrides(spec string) (*levelOverrides, error) {
+	result := &levelOverrides{minLevel: LevelNone}
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		i := strings.LastIndexByte(item, '=')
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid level override '%s'", item)
+		}
+		pattern := strings.TrimSpace(item[:i])
+		if _, error := path.Match(pattern, ""); error != nil {
+			return nil, fmt.Errorf("invalid level override pattern '%s'", pattern)
+		}
+		level := LevelFromString(item[i+1:])
+		if level == LevelInvalid {
+			return nil, fmt.Errorf("invalid level in override '%s'", item)
+		}
+		result.overrides = append(result.overrides, levelOverride{pattern: pattern, level: level})
+		if level < result.minLevel {
+			result.minLevel = level
+		}
+	}
+	if len(result.overrides) == 0 {
+		return nil, nil
+	}
+	return result, nil
+}
+
+// String returns the overrides in the format parsed by parseLevelOverrides.
+func (overrides *levelOverrides) String() string {
+	if overrides == nil {
+		return ""
+	}
+	items := make([]string, len(overrides.overrides))
+	for i, override := range overrides.overrides {
+		items[i] = override.pattern + "=" + ShortStringFromLevel(override.level)
+	}
+	return strings.Join(items, ",")
+}
+
+// levelForPC returns the override level for the caller at pc, or LevelInvalid if no pattern matches.
+func (overrides *levelOverrides) levelForPC(pc uintptr) Level {
+	if level, ok := overrides.cache.Load(pc); ok {
+		return level.(Level)
+	}
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	level := overrides.levelForCaller(packagePath(frame.Function), frame.File)
+	overrides.cache.Store(pc, level)
+	return level
+}
+
+// levelForCaller returns the level of the first pattern that matches the package path or the
+// source file, or LevelInvalid if none match.
+func (overrides *levelOverrides) levelForCaller(packagePath, file string) Level {
+	for _, override := range overrides.overrides {
+		if matchPathSuffix(override.pattern, packagePath) || matchPathSuffix(override.pattern, file) {
+			return override.level
+		}
+	}
+	return LevelInvalid
+}
+
+// packagePath returns the package path of a fully qualified function name.
+func packagePath(function string) string {
+	slash := strings.LastIndexByte(function, '/')
+	if i := strings.IndexByte(function[slash+1:], '.'); i >= 0 {
+		return function[:slash+1+i]
+	}
+	return function
+}
+
+// matchPathSuffix returns true if the glob pattern matches the name or a trailing part of the
+// name that starts after a slash. "scanner" matches "github.com/E-B-Smith/gokit/scanner".
+func matchPathSuffix(pattern, name string) bool {
+	if name == "" {
+		return false
+	}
+	for {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+		i := strings.IndexByte(name, '/')
+		if i < 0 {
+			return false
+		}
+		name = name[i+1:]
+	}
+}
+
+// SetLevelOverrides sets log levels for particular packages or source files, overriding the
+// logger's level. The spec is a comma separated list of pattern=level pairs, such as
+// "scanner=debug,*/http/*=warning". A pattern is a glob that's matched against the caller's package
+// path and source file, and their trailing parts. The first matching pattern sets the level. An
+// empty spec removes the overrides.
+func (logger *Logger) SetLevelOverrides(spec string) error {
+	overrides, error := parseLevelOverrides(spec)
+	if error != nil {
+		return error
+	}
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	logger.levelOverrides = overrides
+	return nil
+}
+
+// LevelOverrides returns the logger's level overrides in the format used by SetLevelOverrides.
+func (logger *Logger) LevelOverrides() string {
+	logger.mutex.RLock()
+	defer logger.mutex.RUnlock()
+	return logger.levelOverrides.String()
+}
+
+// SetLevelOverrides sets the level overrides of the default Logger.
+func SetLevelOverrides(spec string) error { return defaultLogger.SetLevelOverrides(spec) }
+
+// LevelOverrides returns the level overrides of the default Logger.
+func LevelOverrides() string { return defaultLogger.LevelOverrides() }
+
+// enabled returns true if a message at the level should be logged from the caller stackDepth
+// frames up the stack, counted as runtime.Caller counts them.
+func (logger *Logger) enabled(logLevel Level, stackDepth int) bool {
+	logger.mutex.RLock()
+	level, overrides := logger.level, logger.levelOverrides
+	logger.mutex.RUnlock()
+	if overrides == nil {
+		return logLevel >= level
+	}
+	if logLevel < level && logLevel < overrides.minLevel {
+		return false
+	}
+	var pcs [1]uintptr
+	if runtime.Callers(stackDepth+1, pcs[:]) == 0 {
+		return logLevel >= level
+	}
+	return logger.enabledForPC(logLevel, pcs[0])
+}
+
+// enabledForPC returns true if a message at the level should be logged from the caller at pc.
+func (logger *Logger) enabledForPC(logLevel Level, pc uintptr) bool {
+	logger.mutex.RLock()
+	level, overrides := logger.level, logger.levelOverrides
+	logger.mutex.RUnlock()
+	if overrides != nil && pc != 0 {
+		if override := overrides.levelForPC(pc); override != LevelInvalid {
+			level = override
+		}
+	}
+	return logLevel >= level
+}
+
+// minLevel returns the lowest level that's logged by the logger or any of its overrides.
+func (logger *Logger) minLevel() Level {
+	logger.mutex.RLock()
+	defer logger.mutex.RUnlock()
+	if logger.levelOverrides != nil && logger.levelOverrides.minLevel < logger.level {
+		return logger.levelOverrides.minLevel
+	}
+	return logger.level
+}
@@ -0,0 +1,90 @@
+/**
+@file          levels_test.go
+@package       log
+@brief         Test per-package log level overrides.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestLevelFromString(t *testing.T) {
+	tests := map[string]Level{
+		"LevelWarning": LevelWarning,
+		"debug":        LevelDebug,
+		"WARN":         LevelWarning,
+		"Error":        LevelError,
+		"levelinfo":    LevelInfo,
+		"loud":         LevelInvalid,
+	}
+	for s, level := range tests {
+		if l := LevelFromString(s); l != level {
+			t.Errorf("Expected %s for '%s' but found %s.", StringFromLevel(level), s, StringFromLevel(l))
+		}
+	}
+}
+
+func TestLevelOverrides(t *testing.T) {
+	if _, error := parseLevelOverrides("scanner=loud"); error == nil {
+		t.Errorf("Expected an error for an invalid level.")
+	}
+	overrides, error := parseLevelOverrides(" scanner=debug, */http/*=warn ")
+	if error != nil {
+		t.Fatal(error)
+	}
+	if s := overrides.String(); s != "scanner=debug,*/http/*=warning" {
+		t.Errorf("Unexpected overrides '%s'.", s)
+	}
+	tests := []struct {
+		packagePath, file string
+		level             Level
+	}{
+		{"github.com/E-B-Smith/gokit/scanner", "/src/gokit/scanner/scanner.go", LevelDebug},
+		{"example.com/app/http/handlers", "/src/app/http/handlers/user.go", LevelWarning},
+		{"example.com/app/store", "/src/app/store/http.go", LevelInvalid},
+	}
+	for _, test := range tests {
+		if level := overrides.levelForCaller(test.packagePath, test.file); level != test.level {
+			t.Errorf("Expected %s for %s but found %s.",
+				StringFromLevel(test.level), test.packagePath, StringFromLevel(level))
+		}
+	}
+	if p := packagePath("github.com/E-B-Smith/gokit/log.(*Logger).Infof"); p != "github.com/E-B-Smith/gokit/log" {
+		t.Errorf("Unexpected package path '%s'.", p)
+	}
+}
+
+func TestLoggerLevelOverrides(t *testing.T) {
+	var buffer bytes.Buffer
+	logger := NewLogger()
+	logger.SetFilename(t.TempDir() + "/levels.log")
+	logger.AddSink(NewWriterSink(&buffer))
+	defer logger.SetFilename("")
+	logger.SetLogLevel(LevelWarning)
+
+	logger.Debugf("Hidden.")
+	if error := logger.SetLevelOverrides("gokit/log=debug"); error != nil {
+		t.Fatal(error)
+	}
+	logger.Debugf("Shown.")
+	logger.SetLevelOverrides("*/other/*=debug")
+	logger.Debug("Hidden again.")
+	logger.SetLevelOverrides("log=error")
+	logger.Warningf("Hidden by override.")
+	logger.Errorf("Error.")
+
+	s := buffer.String()
+	if strings.Contains(s, "Hidden") || !strings.Contains(s, "Shown.") || !strings.Contains(s, "Error.") {
+		t.Errorf("Unexpected log:\n%s", s)
+	}
+	if logger.LevelOverrides() != "log=error" {
+		t.Errorf("Unexpected overrides '%s'.", logger.LevelOverrides())
+	}
+}
@@ -11,23 +11,17 @@
 Package log provides simple logging with a standardized message format to a log file and optionally Stdout.
 
 The log files are automatically rotated once a day and the oldest log file is removed.
+
+The package level functions write to a default Logger. Create additional Loggers with NewLogger when
+a process needs more than one log destination, such as a separate audit or access log.
 */
 package log
 
 import (
 	"fmt"
-	"io"
-	"math"
-	"os"
 	"path"
-	"path/filepath"
 	"runtime"
-	"sort"
-	"strings"
-	"sync"
-	"syscall"
 	"time"
-	"unicode"
 )
 
 func debugMessagef(format string, args ...interface{}) {
@@ -80,14 +74,14 @@ var levelNames = []string{
 	"LevelNone",
 }
 
-// LevelFromString returns a log Level const from a string representing the const name.
+// LevelFromString returns a log Level const from a string representing the const name or a short name like "warn".
 func LevelFromString(s string) Level {
 	for index := range levelNames {
 		if s == levelNames[index] {
 			return Level(index)
 		}
 	}
-	return LevelInvalid
+	return levelFromShortName(s)
 }
 
 // StringFromLevel returns a string representing the passed log Level const.
@@ -98,214 +92,33 @@ func StringFromLevel(level Level) string {
 	return levelNames[level]
 }
 
-var (
-	mutex     = &sync.RWMutex{}
-	logLevel  = LevelInfo
-	teeStderr bool
-
-	// LogRotationInterval sets how often the log file will be rotated.
-	logRotationInterval = time.Hour * 24.0
+var defaultLogger = NewLogger()
 
-	// Number of old log files to keep
-	logRetentionCount = 1
-
-	logWriter       io.WriteCloser = os.Stderr
-	logFilename     string
-	logRotationTime time.Time
-)
+// DefaultLogger returns the Logger used by the package level log functions.
+func DefaultLogger() *Logger {
+	return defaultLogger
+}
 
 // SetLogLevel sets the minimum log severity level written to the log.
-func SetLogLevel(level Level) {
-	mutex.Lock()
-	defer mutex.Unlock()
-	logLevel = level
-}
+func SetLogLevel(level Level) { defaultLogger.SetLogLevel(level) }
 
 // LogLevel returns the current log level severity level being written to the log.
-func LogLevel() Level {
-	mutex.RLock()
-	defer mutex.RUnlock()
-	return logLevel
-}
+func LogLevel() Level { return defaultLogger.LogLevel() }
 
 // SetTeeStderr when set to true log messages are output to Stderr as well as the log file.
-func SetTeeStderr(value bool) {
-	mutex.Lock()
-	defer mutex.Unlock()
-	teeStderr = value
-}
+func SetTeeStderr(value bool) { defaultLogger.SetTeeStderr(value) }
 
 // TeeStderr returns the current state of TeeStderr. If TeeStderr is true log messages are output to Stderr as well as the log file.
-func TeeStderr() bool {
-	mutex.RLock()
-	defer mutex.RUnlock()
-	return teeStderr
-}
-
-func closeLogFile() {
-	if logWriter != os.Stderr && logWriter != os.Stdout {
-		logWriter.Close()
-	}
-}
-
-func openLogFile() {
-	logRotationTime = time.Unix(math.MaxInt64-1000, 0) //  Distant future
-
-	defer func() {
-		if reason := recover(); reason != nil {
-			logFilename = ""
-			logWriter = os.Stderr
-			Errorf("%s", reason)
-		}
-		name := logFilename
-		if name == "" {
-			name = "Stderr"
-		}
-		// fmt.Fprintf(os.Stderr, "Log file is '%s'.\n", name)
-	}()
-
-	debugMessagef("Logfile: '%s'.", logFilename)
-
-	logFilename = strings.TrimSpace(logFilename)
-	if logFilename == "" {
-		logWriter = os.Stderr
-		return
-	}
-
-	logFilename = absolutePath(logFilename)
-	if len(logFilename) <= 0 {
-		logWriter = os.Stderr
-		return
-	}
-
-	debugMessagef("Logfile: '%s'.", logFilename)
-
-	var error error
-	pathname := filepath.Dir(logFilename)
-	if len(pathname) > 0 {
-		if error = os.MkdirAll(pathname, 0700); error != nil {
-			logWriter = os.Stderr
-			panic(fmt.Sprintf("Can't create directory for log file '%s': %v.", logFilename, error))
-		}
-	}
-
-	debugMessagef("Logfile: '%s'.", pathname)
-
-	var flags = syscall.O_APPEND | syscall.O_CREAT | syscall.O_WRONLY
-	var mode = os.ModeAppend | 0700
-
-	debugMessagef("Logfile: '%s'.", logFilename)
-
-	logWriter, error = os.OpenFile(logFilename, flags, mode)
-	if error != nil {
-		logWriter = os.Stderr
-		panic(fmt.Sprintf("Can't open log file '%s' for writing: %v.", logFilename, error))
-	}
-
-	if logRotationInterval.Seconds() > 0 {
-		var nextTime = (int64(time.Now().Unix()) / int64(logRotationInterval.Seconds())) + 1
-		nextTime *= int64(logRotationInterval.Seconds())
-		logRotationTime = time.Unix(nextTime, 0)
-	}
-}
-
-func rotateLogFile() {
-	if len(logFilename) <= 0 {
-		return
-	}
-
-	defer func() {
-		if reason := recover(); reason != nil {
-			logFilename = ""
-			logWriter = os.Stderr
-			Errorf("%s", reason)
-		}
-		name := logFilename
-		if name == "" {
-			name = "Stderr"
-		}
-		// fmt.Fprintf(os.Stderr, "Log file is '%s'.\n", name)
-	}()
-
-	//  Create a new file for the log --
-
-	baseName := filepath.Base(logFilename)
-	ext := filepath.Ext(baseName)
-	if len(ext) != 0 {
-		baseName = strings.TrimSuffix(baseName, ext)
-	}
-	replacePunct := func(r rune) rune {
-		if unicode.IsLetter(r) || unicode.IsDigit(r) {
-			return r
-		}
-		return '-'
-	}
-	timeString := strings.Map(replacePunct, logRotationTime.Format(time.RFC3339)) + ext
-	newBase := fmt.Sprintf("%s-%s", baseName, timeString)
-	newPath := filepath.Join(filepath.Dir(logFilename), newBase)
-	closeLogFile()
-	error := os.Rename(logFilename, newPath)
-	if error != nil {
-		panic(error)
-	}
-	openLogFile()
-	Infof("Log rotated to '%s'.", newPath)
-	Infof("Log continues in '%s'.", logFilename)
-
-	//  Delete the oldest --
-
-	globPath := filepath.Join(filepath.Dir(logFilename), baseName+"-*")
-	logfiles, error := filepath.Glob(globPath)
-	debugMessagef("Log files: %+v.", logfiles)
-	if error != nil {
-		Error(error)
-		return
-	}
-
-	//  Keep the newest logRetentionCount --
-
-	sortedLogfiles := sort.StringSlice(logfiles)
-	sortedLogfiles.Sort()
-	for i := 0; i < len(sortedLogfiles)-logRetentionCount; i++ {
-		Infof("Removing old log '%s'.", sortedLogfiles[i])
-		error = os.Remove(sortedLogfiles[i])
-		if error != nil {
-			Errorf("Can't remove log file '%s': %v.", sortedLogfiles[i], error)
-		}
-	}
-}
+func TeeStderr() bool { return defaultLogger.TeeStderr() }
 
 // SetFilename sets the filename for the log file. If filename is the empty string log goes to stdout.
-func SetFilename(filename string) {
-	mutex.Lock()
-	if len(filename) > 0 {
-		filename = absolutePath(filename)
-	}
-	if filename == logFilename {
-		mutex.Unlock()
-		return
-	}
-	closeLogFile()
-	logFilename = filename
-	mutex.Unlock()
-	openLogFile()
-}
+func SetFilename(filename string) { defaultLogger.SetFilename(filename) }
 
 // Filename returns the current log file name.
-func Filename() string {
-	mutex.RLock()
-	defer mutex.RUnlock()
-	return logFilename
-}
+func Filename() string { return defaultLogger.Filename() }
 
 // LogStackWithError writes an error and a stack trace to the log.
-func LogStackWithError(error interface{}) {
-	trace := make([]byte, 64000)
-	count := runtime.Stack(trace, false)
-	s := trace[:count]
-	logRaw(LevelError, 2, "'%v'.", error)
-	logRaw(LevelError, 2, "Stack of %d bytes: %s.", count, s)
-}
+func LogStackWithError(error interface{}) { defaultLogger.logStackWithError(3, error) }
 
 // PrettyStackString returns a prettyfied string of the current stack. The `skip` parameter indicates
 // the number of frames to skip before reporting.
@@ -336,89 +149,120 @@ func CurrentFunctionName() string {
 func LogFunctionName() {
 	pc, _, _, _ := runtime.Caller(1)
 	funcname := path.Base(runtime.FuncForPC(pc).Name())
-	logRaw(LevelDebug, 2, "Function %s.", funcname)
+	defaultLogger.logRaw(LevelDebug, 2, "Function %s.", funcname)
 }
 
-// FlushMessages flushes all outstanding log messages to the log file.
-func FlushMessages() {
-	mutex.Lock()
-	defer mutex.Unlock()
-	closeLogFile()
-	openLogFile()
-}
-
-// logRaw logs a raw messgae.
-// stackDepth is the depth in the stack to where the calling source code / line number should be billed.
-func logRaw(logLevel Level, stackDepth int, format string, args ...interface{}) {
-
-	LevelNames := []string{
-		"Inval",
-		"  All",
-		"Debug",
-		" Info",
-		"Start",
-		" Exit",
-		" Warn",
-		"Error",
-		" None",
-	}
-
-	if logLevel < LogLevel() {
-		return
-	}
-	if logLevel < LevelDebug || logLevel > LevelError {
-		logLevel = LevelError
-	}
-
-	var dirname string
-	_, filename, linenumber, _ := runtime.Caller(stackDepth)
-	dirname, filename = path.Split(filename)
-	dirname = path.Base(dirname)
-	i := len(filename)
-	if i > 26 {
-		i = 26
-	}
-	filename = dirname + "/" + filename[:i]
-
-	itemTime := time.Now()
-	if itemTime.After(logRotationTime) {
-		rotateLogFile()
-	}
+// SetEncoder sets the encoder that formats log messages. The default is a TextEncoder.
+func SetEncoder(encoder Encoder) { defaultLogger.SetEncoder(encoder) }
 
-	var message = fmt.Sprintf(format, args...)
-	message = strings.Replace(message, "\n", "|", -1)
-	message = strings.Replace(message, "\r", "|", -1)
-	message = fmt.Sprintf(
-		"%s %26s:%-4d %s: %s\n",
-		itemTime.Format(time.RFC3339),
-		filename,
-		linenumber,
-		LevelNames[logLevel],
-		message,
-	)
-	fmt.Fprint(logWriter, message)
-	if TeeStderr() {
-		fmt.Fprint(os.Stderr, message)
-	}
-}
+// FlushMessages writes any queued log messages, flushes the log file to disk, and flushes the sinks.
+func FlushMessages() { defaultLogger.FlushMessages() }
 
 // Debugf writes a debug level message to the log.
-func Debugf(format string, args ...interface{}) { logRaw(LevelDebug, 2, format, args...) }
+func Debugf(format string, args ...interface{}) { defaultLogger.logRaw(LevelDebug, 2, format, args...) }
 
 // Startf writes a start level message to the log.
-func Startf(format string, args ...interface{}) { logRaw(LevelStart, 2, format, args...) }
+func Startf(format string, args ...interface{}) { defaultLogger.logRaw(LevelStart, 2, format, args...) }
 
 // Exitf writes an exit level message to the log.
-func Exitf(format string, args ...interface{}) { logRaw(LevelExit, 2, format, args...) }
+func Exitf(format string, args ...interface{}) { defaultLogger.logRaw(LevelExit, 2, format, args...) }
 
 // Infof writes an info level message to the log.
-func Infof(format string, args ...interface{}) { logRaw(LevelInfo, 2, format, args...) }
+func Infof(format string, args ...interface{}) { defaultLogger.logRaw(LevelInfo, 2, format, args...) }
 
 // Warningf writes a warning level message to the log.
-func Warningf(format string, args ...interface{}) { logRaw(LevelWarning, 2, format, args...) }
+func Warningf(format string, args ...interface{}) {
+	defaultLogger.logRaw(LevelWarning, 2, format, args...)
+}
 
 // Errorf writes a error level message to the log.
-func Errorf(format string, args ...interface{}) { logRaw(LevelError, 2, format, args...) }
+func Errorf(format string, args ...interface{}) { defaultLogger.logRaw(LevelError, 2, format, args...) }
 
 // Error Writes a error message to the log.
-func Error(error error) { logRaw(LevelError, 2, "%v.", error) }
+func Error(error error) { defaultLogger.logRaw(LevelError, 2, "%v.", error) }
+
+// With returns a child of the default Logger that adds the key / value pairs to each message it writes.
+func With(keyvals ...interface{}) *Logger { return defaultLogger.With(keyvals...) }
+
+// Log writes a message with key / value fields at the passed level to the log.
+func Log(level Level, message string, keyvals ...interface{}) {
+	defaultLogger.logFields(level, 2, message, keyvals)
+}
+
+// Debug writes a debug level message with key / value fields to the log.
+func Debug(message string, keyvals ...interface{}) {
+	defaultLogger.logFields(LevelDebug, 2, message, keyvals)
+}
+
+// Start writes a start level message with key / value fields to the log.
+func Start(message string, keyvals ...interface{}) {
+	defaultLogger.logFields(LevelStart, 2, message, keyvals)
+}
+
+// Exit writes an exit level message with key / value fields to the log.
+func Exit(message string, keyvals ...interface{}) {
+	defaultLogger.logFields(LevelExit, 2, message, keyvals)
+}
+
+// Info writes an info level message with key / value fields to the log.
+func Info(message string, keyvals ...interface{}) {
+	defaultLogger.logFields(LevelInfo, 2, message, keyvals)
+}
+
+// Warning writes a warning level message with key / value fields to the log.
+func Warning(message string, keyvals ...interface{}) {
+	defaultLogger.logFields(LevelWarning, 2, message, keyvals)
+}
+
+// ErrorMessage writes an error level message with key / value fields to the log.
+func ErrorMessage(message string, keyvals ...interface{}) {
+	defaultLogger.logFields(LevelError, 2, message, keyvals)
+}
+
+// SetMaxFileSize sets the size in bytes at which the log file is rotated. Size based rotation works
+// alone or with the rotation interval. A size of zero or less turns off size based rotation.
+func SetMaxFileSize(size int64) { defaultLogger.SetMaxFileSize(size) }
+
+// MaxFileSize returns the size in bytes at which the log file is rotated, or zero if there is no limit.
+func MaxFileSize() int64 { return defaultLogger.MaxFileSize() }
+
+// SetCompressRotated when set to true rotated log files are compressed with gzip in the background.
+func SetCompressRotated(value bool) { defaultLogger.SetCompressRotated(value) }
+
+// CompressRotated returns true if rotated log files are compressed.
+func CompressRotated() bool { return defaultLogger.CompressRotated() }
+
+// SetRotationInterval sets how often the log file is rotated. An interval of zero turns off interval
+// rotation. The default is once a day.
+func SetRotationInterval(interval time.Duration) { defaultLogger.SetRotationInterval(interval) }
+
+// RotationInterval returns how often the log file is rotated.
+func RotationInterval() time.Duration { return defaultLogger.RotationInterval() }
+
+// SetRetentionCount sets the number of rotated log files to keep. A count of zero or less keeps
+// any number of files, subject to the other retention limits. The default is one.
+func SetRetentionCount(count int) { defaultLogger.SetRetentionCount(count) }
+
+// RetentionCount returns the number of rotated log files to keep.
+func RetentionCount() int { return defaultLogger.RetentionCount() }
+
+// SetRetentionMaxAge sets the age at which rotated log files are removed. Zero means no age limit.
+func SetRetentionMaxAge(age time.Duration) { defaultLogger.SetRetentionMaxAge(age) }
+
+// RetentionMaxAge returns the age at which rotated log files are removed.
+func RetentionMaxAge() time.Duration { return defaultLogger.RetentionMaxAge() }
+
+// SetRetentionMaxBytes sets the total size in bytes of all rotated log files. The oldest files are
+// removed first until the rest fit. Zero means no size limit.
+func SetRetentionMaxBytes(size int64) { defaultLogger.SetRetentionMaxBytes(size) }
+
+// RetentionMaxBytes returns the total size in bytes of all rotated log files.
+func RetentionMaxBytes() int64 { return defaultLogger.RetentionMaxBytes() }
+
+// SetAsync turns on asynchronous writing. Log messages are put in a queue of queueSize messages and
+// written by a single writer goroutine. The policy decides what happens when the queue is full.
+// A queueSize of zero or less writes the queued messages and turns asynchronous writing off.
+func SetAsync(queueSize int, policy OverflowPolicy) { defaultLogger.SetAsync(queueSize, policy) }
+
+// DroppedMessages returns the number of messages dropped because the asynchronous queue was full.
+func DroppedMessages() uint64 { return defaultLogger.DroppedMessages() }
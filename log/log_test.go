@@ -50,8 +50,8 @@ func TestLogFileName(t *testing.T) {
 func TestLogRotation(t *testing.T) {
 	SetTeeStderr(false)
 	SetLogLevel(LevelAll)
-	logRotationInterval = time.Second * 2
-	logRetentionCount = 100
+	SetRotationInterval(time.Second * 2)
+	SetRetentionCount(100)
 	logfilename := testLogFileName(t)
 	SetFilename(logfilename)
 
@@ -120,7 +120,7 @@ func TestLogStackWithError(t *testing.T) {
 
 func TestPrettyStackString(t *testing.T) {
 	s := PrettyStackString(0)
-	r := "log.go:314\nlog_test.go:122\ntesting.go:"
+	r := "log.go:127\nlog_test.go:122\ntesting.go:"
 	if !(len(s) > len(r) && strings.HasPrefix(s, r)) {
 		t.Errorf("Expected\n%s\nbut found\n%s.", r, s)
 	}
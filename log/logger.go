@@ -0,0 +1,648 @@
+/**
+@file          logger.go
+@package       log
+@brief         A log destination with its own file, level, and rotation policy.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+	"unicode"
+)
+
+// distantFuture is the rotation time of a log that isn't rotated on an interval.
+var distantFuture = time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+// Logger writes log messages to its own log file with its own log level and rotation policy.
+// The package level functions write to the default Logger.
+type Logger struct {
+	*loggerCore
+
+	// Fields added to every message written by this logger.
+	fields []Field
+}
+
+// loggerCore is the log destination and settings shared by a Logger and the children made by With.
+type loggerCore struct {
+	mutex     sync.RWMutex
+	level     Level
+	teeStderr bool
+	encoder   Encoder
+	sinks     []Sink
+
+	// Formats the messages teed to Stderr. Nil tees the encoded log line.
+	teeEncoder Encoder
+
+	// Levels for particular packages or source files that override level.
+	levelOverrides *levelOverrides
+
+	// Masks secrets in messages before they're encoded.
+	redactor *Redactor
+
+	// Rate limits, samples, and collapses repeated messages.
+	throttle throttle
+
+	// How often the log file will be rotated.
+	rotationInterval time.Duration
+
+	// The log file is rotated when it grows past maxFileSize bytes. Zero means no size limit.
+	maxFileSize int64
+
+	// Rotated log files are removed when there are more than retentionCount, when they're older than
+	// retentionMaxAge, or when together they're larger than retentionMaxBytes. Zero means no limit.
+	retentionCount    int
+	retentionMaxAge   time.Duration
+	retentionMaxBytes int64
+
+	// Rotated log files are compressed in the background when compressRotated is true.
+	compressRotated bool
+	archiving       archiveGroup
+	compressing     map[string]bool
+	retentionMutex  sync.Mutex
+
+	// The log file is reopened if it's moved or truncated, checking at most once a reopenCheckInterval.
+	reopenCheckInterval time.Duration
+	lastReopenCheck     time.Time
+
+	// Messages are written by a goroutine from the queue when the logger is asynchronous.
+	asyncMutex sync.RWMutex
+	queue      *asyncQueue
+	dropped    atomic.Uint64
+
+	// writeMutex serializes writes to the log file with rotation.
+	writeMutex   sync.Mutex
+	writer       io.WriteCloser
+	filename     string
+	rotationTime time.Time
+	fileSize     int64
+}
+
+// NewLogger returns a new Logger that writes to Stderr at LevelInfo. Use SetFilename to log to a file.
+func NewLogger() *Logger {
+	logger := &Logger{
+		loggerCore: &loggerCore{
+			level:            LevelInfo,
+			encoder:          TextEncoder{},
+			rotationInterval: time.Hour * 24.0,
+			retentionCount:   1,
+			writer:           os.Stderr,
+			rotationTime:     distantFuture,
+		},
+	}
+	return logger
+}
+
+// With returns a child Logger that adds the key / value pairs to each message it writes.
+// The child shares the log file, level, and other settings of its parent.
+func (logger *Logger) With(keyvals ...interface{}) *Logger {
+	return &Logger{
+		loggerCore: logger.loggerCore,
+		fields:     appendFields(logger.fields, keyvals),
+	}
+}
+
+// Fields returns the key / value fields that the logger adds to each message.
+func (logger *Logger) Fields() []Field {
+	return append([]Field(nil), logger.fields...)
+}
+
+// SetLogLevel sets the minimum log severity level written to the log.
+func (logger *Logger) SetLogLevel(level Level) {
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	logger.level = level
+}
+
+// LogLevel returns the current log level severity level being written to the log.
+func (logger *Logger) LogLevel() Level {
+	logger.mutex.RLock()
+	defer logger.mutex.RUnlock()
+	return logger.level
+}
+
+// SetTeeStderr when set to true log messages are output to Stderr as well as the log file.
+func (logger *Logger) SetTeeStderr(value bool) {
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	logger.teeStderr = value
+}
+
+// TeeStderr returns the current state of TeeStderr. If TeeStderr is true log messages are output to Stderr as well as the log file.
+func (logger *Logger) TeeStderr() bool {
+	logger.mutex.RLock()
+	defer logger.mutex.RUnlock()
+	return logger.teeStderr
+}
+
+// SetEncoder sets the encoder that formats log messages. The default is a TextEncoder.
+func (logger *Logger) SetEncoder(encoder Encoder) {
+	if encoder == nil {
+		encoder = TextEncoder{}
+	}
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	logger.encoder = encoder
+}
+
+// Encoder returns the encoder that formats log messages.
+func (logger *Logger) Encoder() Encoder {
+	logger.mutex.RLock()
+	defer logger.mutex.RUnlock()
+	return logger.encoder
+}
+
+func (logger *Logger) closeLogFile() {
+	if logger.writer != os.Stderr && logger.writer != os.Stdout {
+		logger.writer.Close()
+	}
+}
+
+// openLogFile opens the log file. The caller holds writeMutex.
+func (logger *Logger) openLogFile() {
+	logger.rotationTime = distantFuture
+
+	defer func() {
+		if reason := recover(); reason != nil {
+			logger.storeFilename("")
+			logger.writer = os.Stderr
+			logger.logLocked(LevelError, "%s", reason)
+		}
+	}()
+
+	debugMessagef("Logfile: '%s'.", logger.filename)
+
+	filename := strings.TrimSpace(logger.filename)
+	if filename != "" {
+		filename = absolutePath(filename)
+	}
+	logger.storeFilename(filename)
+	if len(logger.filename) <= 0 {
+		logger.writer = os.Stderr
+		return
+	}
+
+	var error error
+	pathname := filepath.Dir(logger.filename)
+	if len(pathname) > 0 {
+		if error = os.MkdirAll(pathname, 0700); error != nil {
+			logger.writer = os.Stderr
+			panic(fmt.Sprintf("Can't create directory for log file '%s': %v.", logger.filename, error))
+		}
+	}
+
+	debugMessagef("Logfile: '%s'.", pathname)
+
+	var flags = syscall.O_APPEND | syscall.O_CREAT | syscall.O_WRONLY
+	var mode = os.ModeAppend | 0700
+
+	file, error := os.OpenFile(logger.filename, flags, mode)
+	if error != nil {
+		logger.writer = os.Stderr
+		panic(fmt.Sprintf("Can't open log file '%s' for writing: %v.", logger.filename, error))
+	}
+	logger.writer = file
+	logger.fileSize = 0
+	if info, error := file.Stat(); error == nil {
+		logger.fileSize = info.Size()
+	}
+
+	logger.updateRotationTime()
+}
+
+// storeFilename sets the log file name. The caller holds writeMutex, and the name is set while
+// holding mutex as well so that Filename can read it while messages are written.
+func (logger *Logger) storeFilename(filename string) {
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	logger.filename = filename
+}
+
+// updateRotationTime sets the time of the next interval rotation. The caller holds writeMutex.
+func (logger *Logger) updateRotationTime() {
+	logger.rotationTime = distantFuture
+	rotationInterval := logger.RotationInterval()
+	if logger.filename != "" && rotationInterval.Seconds() >= 1 {
+		var nextTime = (int64(time.Now().Unix()) / int64(rotationInterval.Seconds())) + 1
+		nextTime *= int64(rotationInterval.Seconds())
+		logger.rotationTime = time.Unix(nextTime, 0)
+	}
+}
+
+// rotateLogFile renames the log file and opens a new one. It returns the new name of the rotated file,
+// or the empty string if the log wasn't rotated. The caller holds writeMutex.
+func (logger *Logger) rotateLogFile() (newPath string) {
+	if len(logger.filename) <= 0 {
+		return ""
+	}
+
+	defer func() {
+		if reason := recover(); reason != nil {
+			logger.storeFilename("")
+			logger.writer = os.Stderr
+			logger.logLocked(LevelError, "%s", reason)
+			newPath = ""
+		}
+	}()
+
+	//  Create a new file for the log --
+
+	baseName := filepath.Base(logger.filename)
+	ext := filepath.Ext(baseName)
+	if len(ext) != 0 {
+		baseName = strings.TrimSuffix(baseName, ext)
+	}
+	replacePunct := func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '-'
+	}
+	rotationTime := logger.rotationTime
+	if rotationTime.Equal(distantFuture) {
+		rotationTime = time.Now()
+	}
+	timeString := strings.Map(replacePunct, rotationTime.Format(time.RFC3339))
+	newPath = filepath.Join(filepath.Dir(logger.filename), baseName+"-"+timeString+ext)
+	for sequence := 1; fileExists(newPath) || fileExists(newPath+compressSuffix); sequence++ {
+		//  Rotated more than once in the same time slot --
+		newBase := fmt.Sprintf("%s-%s-%d%s", baseName, timeString, sequence, ext)
+		newPath = filepath.Join(filepath.Dir(logger.filename), newBase)
+	}
+	logger.closeLogFile()
+	error := os.Rename(logger.filename, newPath)
+	if error != nil {
+		panic(error)
+	}
+	logger.openLogFile()
+	logger.logLocked(LevelInfo, "Log rotated to '%s'.", newPath)
+	logger.logLocked(LevelInfo, "Log continues in '%s'.", logger.filename)
+	return newPath
+}
+
+// archiveRotatedLogFile compresses the rotated log file if needed and then removes the oldest log files.
+// The caller must not hold writeMutex.
+func (logger *Logger) archiveRotatedLogFile(rotatedPath string) {
+	if rotatedPath == "" {
+		return
+	}
+	filename := filepath.Join(filepath.Dir(rotatedPath), filepath.Base(logger.Filename()))
+	if !logger.CompressRotated() {
+		logger.removeOldLogFiles(filename)
+		return
+	}
+	logger.retentionMutex.Lock()
+	if logger.compressing == nil {
+		logger.compressing = make(map[string]bool)
+	}
+	logger.compressing[rotatedPath] = true
+	logger.retentionMutex.Unlock()
+
+	//  The flush list is updated by the goroutine since the asynchronous writer can get here while
+	//  SetAsync holds asyncMutex --
+
+	logger.archiving.Add(1)
+	go func() {
+		defer logger.updateFlush()
+		defer logger.archiving.Done()
+		logger.updateFlush()
+		error := compressLogFile(rotatedPath)
+		logger.retentionMutex.Lock()
+		delete(logger.compressing, rotatedPath)
+		logger.retentionMutex.Unlock()
+		if error != nil {
+			logger.logArchiveMessages([]archiveMessage{
+				{LevelError, fmt.Sprintf("Can't compress log file '%s': %v.", rotatedPath, error)},
+			})
+		}
+		logger.removeOldLogFiles(filename)
+	}()
+}
+
+// SetCompressRotated when set to true rotated log files are compressed with gzip in the background.
+func (logger *Logger) SetCompressRotated(value bool) {
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	logger.compressRotated = value
+}
+
+// CompressRotated returns true if rotated log files are compressed.
+func (logger *Logger) CompressRotated() bool {
+	logger.mutex.RLock()
+	defer logger.mutex.RUnlock()
+	return logger.compressRotated
+}
+
+// SetRotationInterval sets how often the log file is rotated. An interval of zero turns off interval
+// rotation. The default is once a day.
+func (logger *Logger) SetRotationInterval(interval time.Duration) {
+	logger.mutex.Lock()
+	logger.rotationInterval = interval
+	logger.mutex.Unlock()
+
+	logger.writeMutex.Lock()
+	defer logger.writeMutex.Unlock()
+	logger.updateRotationTime()
+}
+
+// RotationInterval returns how often the log file is rotated.
+func (logger *Logger) RotationInterval() time.Duration {
+	logger.mutex.RLock()
+	defer logger.mutex.RUnlock()
+	return logger.rotationInterval
+}
+
+// SetMaxFileSize sets the size in bytes at which the log file is rotated. Size based rotation works
+// alone or with the rotation interval. A size of zero or less turns off size based rotation.
+func (logger *Logger) SetMaxFileSize(size int64) {
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	logger.maxFileSize = size
+}
+
+// MaxFileSize returns the size in bytes at which the log file is rotated, or zero if there is no limit.
+func (logger *Logger) MaxFileSize() int64 {
+	logger.mutex.RLock()
+	defer logger.mutex.RUnlock()
+	return logger.maxFileSize
+}
+
+// SetFilename sets the filename for the log file. If filename is the empty string log goes to stdout.
+func (logger *Logger) SetFilename(filename string) {
+	if len(filename) > 0 {
+		filename = absolutePath(filename)
+	}
+	logger.drainQueue()
+	logger.writeMutex.Lock()
+	defer logger.writeMutex.Unlock()
+	logger.mutex.Lock()
+	if filename == logger.filename && (filename != "" || logger.writer == os.Stderr) {
+		logger.mutex.Unlock()
+		return
+	}
+	logger.closeLogFile()
+	logger.filename = filename
+	logger.mutex.Unlock()
+	logger.openLogFile()
+}
+
+// Filename returns the current log file name.
+func (logger *Logger) Filename() string {
+	logger.mutex.RLock()
+	defer logger.mutex.RUnlock()
+	return logger.filename
+}
+
+// outputWriter is a writer set with SetOutput. It isn't closed by the logger.
+type outputWriter struct {
+	io.Writer
+}
+
+func (outputWriter) Close() error { return nil }
+
+// SetOutput sets the writer that log messages are written to instead of a log file. The writer isn't
+// closed by the logger. Use SetFilename to log to a file again.
+func (logger *Logger) SetOutput(writer io.Writer) {
+	if writer == nil {
+		writer = os.Stderr
+	}
+	logger.drainQueue()
+	logger.writeMutex.Lock()
+	defer logger.writeMutex.Unlock()
+	logger.mutex.Lock()
+	logger.closeLogFile()
+	logger.filename = ""
+	logger.mutex.Unlock()
+	logger.writer = outputWriter{writer}
+	if writer == os.Stderr {
+		logger.writer = os.Stderr
+	}
+	logger.fileSize = 0
+	logger.rotationTime = distantFuture
+}
+
+// Output returns the writer that log messages are written to.
+func (logger *Logger) Output() io.Writer {
+	logger.writeMutex.Lock()
+	defer logger.writeMutex.Unlock()
+	if output, ok := logger.writer.(outputWriter); ok {
+		return output.Writer
+	}
+	return logger.writer
+}
+
+// FlushMessages writes any queued log messages and repeat counts, flushes the log file to disk, and flushes the sinks.
+func (logger *Logger) FlushMessages() {
+	logger.flushRepeats()
+	logger.drainQueue()
+	logger.writeMutex.Lock()
+	if file, ok := logger.writer.(*os.File); ok && file != os.Stderr && file != os.Stdout {
+		file.Sync()
+	}
+	logger.writeMutex.Unlock()
+	logger.flushSinks()
+}
+
+// LogStackWithError writes an error and a stack trace to the log.
+func (logger *Logger) LogStackWithError(error interface{}) {
+	logger.logStackWithError(3, error)
+}
+
+func (logger *Logger) logStackWithError(stackDepth int, error interface{}) {
+	trace := make([]byte, 64000)
+	count := runtime.Stack(trace, false)
+	s := trace[:count]
+	logger.logRaw(LevelError, stackDepth, "'%v'.", error)
+	logger.logRaw(LevelError, stackDepth, "Stack of %d bytes: %s.", count, s)
+}
+
+// LogFunctionName logs the current function name to the log.
+func (logger *Logger) LogFunctionName() {
+	pc, _, _, _ := runtime.Caller(1)
+	funcname := path.Base(runtime.FuncForPC(pc).Name())
+	logger.logRaw(LevelDebug, 2, "Function %s.", funcname)
+}
+
+// logRaw logs a raw messgae.
+// stackDepth is the depth in the stack to where the calling source code / line number should be billed.
+func (logger *Logger) logRaw(logLevel Level, stackDepth int, format string, args ...interface{}) {
+	if !logger.enabled(logLevel, stackDepth+1) {
+		return
+	}
+	logger.logEntry(logLevel, stackDepth+1, fmt.Sprintf(format, args...), logger.fields)
+}
+
+// logFields logs a message with the key / value pairs in keyvals added to the logger's fields.
+func (logger *Logger) logFields(logLevel Level, stackDepth int, message string, keyvals []interface{}) {
+	if !logger.enabled(logLevel, stackDepth+1) {
+		return
+	}
+	logger.logEntry(logLevel, stackDepth+1, message, appendFields(logger.fields, keyvals))
+}
+
+func (logger *Logger) logEntry(logLevel Level, stackDepth int, message string, fields []Field) {
+	entry := &Entry{
+		Time:    time.Now(),
+		Level:   logLevel,
+		Message: message,
+		Fields:  fields,
+	}
+	entry.PC, entry.File, entry.Line, _ = runtime.Caller(stackDepth)
+	logger.writeEntry(entry)
+}
+
+// writeEntry encodes the entry and writes it to the log.
+func (logger *Logger) writeEntry(entry *Entry) {
+	if entry.Level < LevelDebug || entry.Level > LevelError {
+		entry.Level = LevelError
+	}
+	entry, summary := logger.allow(entry)
+	if summary != nil {
+		logger.writeAllowedEntry(summary)
+	}
+	if entry != nil {
+		logger.writeAllowedEntry(entry)
+	}
+}
+
+// writeAllowedEntry encodes and writes an entry that has passed the rate limits.
+func (logger *Logger) writeAllowedEntry(entry *Entry) {
+	if redactor := logger.Redactor(); redactor != nil {
+		entry = redactor.RedactEntry(entry)
+	}
+
+	line := logger.Encoder().Encode(entry)
+	if !logger.enqueue(entry, line) {
+		logger.archiveRotatedLogFile(logger.writeEncoded(entry, line))
+	}
+}
+
+// writeEncoded writes the encoded entry to the log, rotating the log file first if it's due. It
+// returns the name of the rotated log file, which the caller passes to archiveRotatedLogFile.
+func (logger *Logger) writeEncoded(entry *Entry, line []byte) (rotatedPath string) {
+	maxFileSize := logger.MaxFileSize()
+
+	logger.writeMutex.Lock()
+	defer logger.writeMutex.Unlock()
+	logger.checkReopen(entry.Time)
+	if entry.Time.After(logger.rotationTime) ||
+		(maxFileSize > 0 && logger.fileSize >= maxFileSize) {
+		rotatedPath = logger.rotateLogFile()
+	}
+	logger.writeLine(entry, line)
+	for _, sink := range logger.Sinks() {
+		sink.WriteEntry(entry, line)
+	}
+	return rotatedPath
+}
+
+// writeLine writes an encoded line to the log file and Stderr. The caller holds writeMutex.
+func (logger *Logger) writeLine(entry *Entry, line []byte) {
+	n, _ := logger.writer.Write(line)
+	logger.fileSize += int64(n)
+	if logger.TeeStderr() {
+		if encoder := logger.TeeEncoder(); encoder != nil {
+			line = encoder.Encode(entry)
+		}
+		os.Stderr.Write(line)
+	}
+}
+
+// logLocked writes a message about the log itself while the caller holds writeMutex.
+func (logger *Logger) logLocked(logLevel Level, format string, args ...interface{}) {
+	if logLevel < logger.LogLevel() {
+		return
+	}
+	entry := &Entry{
+		Time:    time.Now(),
+		Level:   logLevel,
+		Message: fmt.Sprintf(format, args...),
+		Fields:  logger.fields,
+	}
+	entry.PC, entry.File, entry.Line, _ = runtime.Caller(1)
+	logger.writeLine(entry, logger.Encoder().Encode(entry))
+}
+
+// Debugf writes a debug level message to the log.
+func (logger *Logger) Debugf(format string, args ...interface{}) {
+	logger.logRaw(LevelDebug, 2, format, args...)
+}
+
+// Startf writes a start level message to the log.
+func (logger *Logger) Startf(format string, args ...interface{}) {
+	logger.logRaw(LevelStart, 2, format, args...)
+}
+
+// Exitf writes an exit level message to the log.
+func (logger *Logger) Exitf(format string, args ...interface{}) {
+	logger.logRaw(LevelExit, 2, format, args...)
+}
+
+// Infof writes an info level message to the log.
+func (logger *Logger) Infof(format string, args ...interface{}) {
+	logger.logRaw(LevelInfo, 2, format, args...)
+}
+
+// Warningf writes a warning level message to the log.
+func (logger *Logger) Warningf(format string, args ...interface{}) {
+	logger.logRaw(LevelWarning, 2, format, args...)
+}
+
+// Errorf writes a error level message to the log.
+func (logger *Logger) Errorf(format string, args ...interface{}) {
+	logger.logRaw(LevelError, 2, format, args...)
+}
+
+// Error Writes a error message to the log.
+func (logger *Logger) Error(error error) { logger.logRaw(LevelError, 2, "%v.", error) }
+
+// Log writes a message with key / value fields at the passed level to the log.
+func (logger *Logger) Log(level Level, message string, keyvals ...interface{}) {
+	logger.logFields(level, 2, message, keyvals)
+}
+
+// Debug writes a debug level message with key / value fields to the log.
+func (logger *Logger) Debug(message string, keyvals ...interface{}) {
+	logger.logFields(LevelDebug, 2, message, keyvals)
+}
+
+// Start writes a start level message with key / value fields to the log.
+func (logger *Logger) Start(message string, keyvals ...interface{}) {
+	logger.logFields(LevelStart, 2, message, keyvals)
+}
+
+// Exit writes an exit level message with key / value fields to the log.
+func (logger *Logger) Exit(message string, keyvals ...interface{}) {
+	logger.logFields(LevelExit, 2, message, keyvals)
+}
+
+// Info writes an info level message with key / value fields to the log.
+func (logger *Logger) Info(message string, keyvals ...interface{}) {
+	logger.logFields(LevelInfo, 2, message, keyvals)
+}
+
+// Warning writes a warning level message with key / value fields to the log.
+func (logger *Logger) Warning(message string, keyvals ...interface{}) {
+	logger.logFields(LevelWarning, 2, message, keyvals)
+}
+
+// ErrorMessage writes an error level message with key / value fields to the log. It's the
+// structured form of Error, which takes an error value:
+//
+//	logger.ErrorMessage("Can't write shard.", "shard", 7, "err", error)
+func (logger *Logger) ErrorMessage(message string, keyvals ...interface{}) {
+	logger.logFields(LevelError, 2, message, keyvals)
+}
@@ -0,0 +1,96 @@
+/**
+@file          logger_test.go
+@package       log
+@brief         Test the Logger type.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSeparateLoggers(t *testing.T) {
+	dir := t.TempDir()
+
+	audit := NewLogger()
+	audit.SetFilename(filepath.Join(dir, "audit.log"))
+	audit.SetLogLevel(LevelDebug)
+
+	access := NewLogger()
+	access.SetFilename(filepath.Join(dir, "access.log"))
+	access.SetLogLevel(LevelWarning)
+
+	audit.Debugf("Audit message.")
+	access.Infof("Filtered access message.")
+	access.Warningf("Access message.")
+	audit.SetFilename("")
+	access.SetFilename("")
+
+	b, _ := os.ReadFile(filepath.Join(dir, "audit.log"))
+	if !strings.Contains(string(b), "Debug: Audit message.") || strings.Contains(string(b), "Access") {
+		t.Errorf("Unexpected audit log:\n%s", b)
+	}
+	b, _ = os.ReadFile(filepath.Join(dir, "access.log"))
+	if !strings.Contains(string(b), " Warn: Access message.") || strings.Contains(string(b), "Filtered") {
+		t.Errorf("Unexpected access log:\n%s", b)
+	}
+	if !strings.Contains(string(b), "log/logger_test.go:") {
+		t.Errorf("Expected caller in access log:\n%s", b)
+	}
+}
+
+func TestSizeRotation(t *testing.T) {
+	dir := t.TempDir()
+	logger := NewLogger()
+	logger.SetRotationInterval(0)
+	logger.SetRetentionCount(100)
+	logger.SetMaxFileSize(300)
+	logger.SetFilename(filepath.Join(dir, "size.log"))
+	for i := 0; i < 20; i++ {
+		logger.Infof("Message %d.", i)
+	}
+	logger.SetFilename("")
+
+	logfiles, _ := filepath.Glob(filepath.Join(dir, "size*"))
+	if len(logfiles) < 5 {
+		t.Errorf("Expected at least 5 files, found %d.", len(logfiles))
+	}
+	count := 0
+	for _, logfile := range logfiles {
+		b, _ := os.ReadFile(logfile)
+		count += strings.Count(string(b), ": Message ")
+		if len(b) > 600 {
+			t.Errorf("File '%s' is too large: %d bytes.", logfile, len(b))
+		}
+	}
+	if count != 20 {
+		t.Errorf("Expected 20 messages, found %d.", count)
+	}
+}
+
+func TestSetOutput(t *testing.T) {
+	var buffer bytes.Buffer
+	filename := filepath.Join(t.TempDir(), "output.log")
+	logger := NewLogger()
+	logger.SetFilename(filename)
+	logger.SetOutput(&buffer)
+	logger.Infof("To the buffer.")
+	if logger.Filename() != "" || logger.Output() != &buffer || !strings.HasSuffix(buffer.String(), "Info: To the buffer.\n") {
+		t.Errorf("Unexpected output '%s'.", buffer.String())
+	}
+	logger.SetFilename("")
+	if logger.Output() != os.Stderr {
+		t.Errorf("Expected Stderr after SetFilename.")
+	}
+	if b, _ := os.ReadFile(filename); strings.Contains(string(b), "buffer") {
+		t.Errorf("Unexpected log file '%s'.", b)
+	}
+}
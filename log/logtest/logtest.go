@@ -0,0 +1,168 @@
+/**
+@file          logtest.go
+@package       logtest
+@brief         Captures log entries in memory so that tests can check what was logged.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+/*
+Package logtest captures the entries written to a log during a test so that the test can check
+them without reading a log file back:
+
+	func TestSignIn(t *testing.T) {
+	    capture := logtest.Capture(t)
+	    SignIn("jane")
+	    capture.AssertLogged(log.LevelInfo, `^Signed in\. user=jane$`)
+	}
+*/
+package logtest
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/E-B-Smith/gokit/log"
+)
+
+// DefaultCaptureSize is the number of entries kept by the Recorders returned by Capture and
+// CaptureLogger. Use CaptureLoggerSize to keep more.
+const DefaultCaptureSize = 1000
+
+// Recorder holds the entries written to a logger during a test.
+type Recorder struct {
+	t      testing.TB
+	logger *log.Logger
+	ring   *log.RingSink
+}
+
+// Capture records the entries written to the default Logger until the test ends. See CaptureLogger.
+// The default Logger is shared by the whole test binary, so Capture isn't safe to use in tests that
+// call t.Parallel: their entries would be recorded by each other's captures, and the restores at
+// the end of each test would race. Give parallel tests their own Logger and use CaptureLogger.
+func Capture(t testing.TB) *Recorder {
+	return CaptureLogger(t, log.DefaultLogger())
+}
+
+// CaptureLogger records the entries written to the logger until the test ends. While capturing,
+// the logger writes to an in-memory sink instead of its log file, Stderr, and its other sinks, so
+// that test output doesn't reach a network or syslog sink. The logger's level isn't changed, so
+// tests can check that messages below it are filtered. The logger's destination, sinks, level, and
+// TeeStderr are restored when the test ends. The last DefaultCaptureSize entries are kept.
+func CaptureLogger(t testing.TB, logger *log.Logger) *Recorder {
+	t.Helper()
+	return CaptureLoggerSize(t, logger, DefaultCaptureSize)
+}
+
+// CaptureLoggerSize is like CaptureLogger but keeps the last size entries, for tests that log more
+// than DefaultCaptureSize entries.
+func CaptureLoggerSize(t testing.TB, logger *log.Logger, size int) *Recorder {
+	t.Helper()
+	logger.FlushMessages()
+	filename, output := logger.Filename(), logger.Output()
+	level, tee, sinks := logger.LogLevel(), logger.TeeStderr(), logger.Sinks()
+
+	recorder := &Recorder{t: t, logger: logger, ring: log.NewRingSink(size)}
+	logger.SetOutput(io.Discard)
+	logger.SetTeeStderr(false)
+	for _, sink := range sinks {
+		logger.RemoveSink(sink)
+	}
+	logger.AddSink(recorder.ring)
+
+	t.Cleanup(func() {
+		logger.FlushMessages()
+		logger.RemoveSink(recorder.ring)
+		for _, sink := range sinks {
+			logger.AddSink(sink)
+		}
+		if filename != "" {
+			logger.SetFilename(filename)
+		} else {
+			logger.SetOutput(output)
+		}
+		logger.SetLogLevel(level)
+		logger.SetTeeStderr(tee)
+	})
+	return recorder
+}
+
+// Entries returns the entries recorded so far, oldest first.
+func (recorder *Recorder) Entries() []*log.Entry {
+	recorder.logger.FlushMessages()
+	return recorder.ring.Entries(log.RingQuery{})
+}
+
+// Messages returns the messages of the entries recorded so far.
+func (recorder *Recorder) Messages() []string {
+	var messages []string
+	for _, entry := range recorder.Entries() {
+		messages = append(messages, entry.Message)
+	}
+	return messages
+}
+
+// Reset removes the recorded entries.
+func (recorder *Recorder) Reset() {
+	recorder.logger.FlushMessages()
+	recorder.ring.Reset()
+}
+
+// Text returns the entry's message followed by its fields as key=value pairs. This is the text
+// that Find and AssertLogged match.
+func Text(entry *log.Entry) string {
+	var builder strings.Builder
+	builder.WriteString(entry.Message)
+	for _, field := range entry.Fields {
+		fmt.Fprintf(&builder, " %s=%v", field.Key, field.Value)
+	}
+	return builder.String()
+}
+
+// Find returns the recorded entries at the level whose text matches the regular expression.
+// LevelAll matches every level.
+func (recorder *Recorder) Find(level log.Level, pattern string) []*log.Entry {
+	recorder.t.Helper()
+	expression, error := regexp.Compile(pattern)
+	if error != nil {
+		recorder.t.Fatalf("Invalid pattern '%s': %v.", pattern, error)
+		return nil
+	}
+	var result []*log.Entry
+	for _, entry := range recorder.Entries() {
+		if (level == log.LevelAll || entry.Level == level) && expression.MatchString(Text(entry)) {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+// AssertLogged fails the test if no entry at the level matches the regular expression.
+func (recorder *Recorder) AssertLogged(level log.Level, pattern string) {
+	recorder.t.Helper()
+	if len(recorder.Find(level, pattern)) == 0 {
+		recorder.t.Errorf("Expected a %s entry matching '%s' in:\n%s", log.StringFromLevel(level), pattern, recorder.dump())
+	}
+}
+
+// AssertNotLogged fails the test if an entry at the level matches the regular expression.
+func (recorder *Recorder) AssertNotLogged(level log.Level, pattern string) {
+	recorder.t.Helper()
+	if entries := recorder.Find(level, pattern); len(entries) > 0 {
+		recorder.t.Errorf("Unexpected %s entry matching '%s': %s", log.StringFromLevel(level), pattern, Text(entries[0]))
+	}
+}
+
+// dump returns the recorded entries, one per line, for a failure message.
+func (recorder *Recorder) dump() string {
+	var builder strings.Builder
+	for _, entry := range recorder.Entries() {
+		fmt.Fprintf(&builder, "  %s %s:%d %s\n",
+			log.ShortStringFromLevel(entry.Level), entry.Caller(), entry.Line, Text(entry))
+	}
+	return builder.String()
+}
@@ -0,0 +1,92 @@
+/**
+@file          logtest_test.go
+@package       logtest
+@brief         Test capturing log entries in tests.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package logtest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/E-B-Smith/gokit/log"
+)
+
+// failureRecorder records test failures instead of failing the test.
+type failureRecorder struct {
+	testing.TB
+	failures []string
+}
+
+func (recorder *failureRecorder) Errorf(format string, args ...interface{}) {
+	recorder.failures = append(recorder.failures, fmt.Sprintf(format, args...))
+}
+
+func TestCapture(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "app.log")
+	logger := log.NewLogger()
+	logger.SetFilename(filename)
+	defer logger.SetFilename("")
+	sink := log.NewRingSink(10)
+	logger.AddSink(sink)
+
+	t.Run("capture", func(t *testing.T) {
+		capture := CaptureLogger(t, logger)
+		logger.Debug("Filtered.")
+		capture.AssertNotLogged(log.LevelDebug, `Filtered`)
+
+		logger.SetLogLevel(log.LevelDebug)
+		logger.Debug("Loaded.", "items", 3)
+		logger.With("user", "jane").Warningf("Slow sign in.")
+
+		capture.AssertLogged(log.LevelDebug, `^Loaded\. items=3$`)
+		capture.AssertLogged(log.LevelWarning, `user=jane`)
+		capture.AssertNotLogged(log.LevelAll, `Error`)
+		entries := capture.Entries()
+		if len(entries) != 2 || !strings.HasPrefix(entries[0].Caller(), "logtest/logtest_test.go") {
+			t.Errorf("Unexpected entries %+v.", entries)
+		}
+
+		failures := &failureRecorder{TB: t}
+		capture.t = failures
+		capture.AssertLogged(log.LevelError, `Loaded`)
+		capture.AssertNotLogged(log.LevelDebug, `Loaded`)
+		if len(failures.failures) != 2 || !strings.Contains(failures.failures[0], "debug logtest/logtest_test.go") {
+			t.Errorf("Unexpected failures %q.", failures.failures)
+		}
+		capture.t = t
+		capture.Reset()
+		if len(capture.Messages()) != 0 {
+			t.Errorf("Expected no messages after a reset.")
+		}
+	})
+
+	logger.Infof("After.")
+	logger.FlushMessages()
+	b, _ := os.ReadFile(filename)
+	if logger.Filename() != filename || logger.LogLevel() != log.LevelInfo || strings.Contains(string(b), "Loaded") ||
+		!strings.Contains(string(b), "After.") {
+		t.Errorf("Expected the logger to be restored, found:\n%s", b)
+	}
+	if messages := sink.Entries(log.RingQuery{}); len(messages) != 1 || messages[0].Message != "After." {
+		t.Errorf("Expected the sink to be detached while capturing, found %+v.", messages)
+	}
+}
+
+func TestCaptureLoggerSize(t *testing.T) {
+	logger := log.NewLogger()
+	capture := CaptureLoggerSize(t, logger, 2)
+	for _, message := range []string{"One.", "Two.", "Three."} {
+		logger.Infof(message)
+	}
+	if messages := strings.Join(capture.Messages(), " "); messages != "Two. Three." {
+		t.Errorf("Expected the last two messages, found '%s'.", messages)
+	}
+}
@@ -0,0 +1,304 @@
+/**
+@file          middleware.go
+@package       log
+@brief         HTTP middleware that writes access logs and recovers from panics.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AccessLogFormat is the format of the lines written by an AccessLog.
+type AccessLogFormat int32
+
+const (
+	// AccessLogCombined writes lines in the Apache combined log format.
+	AccessLogCombined AccessLogFormat = iota
+
+	// AccessLogJSON writes each request as a JSON object.
+	AccessLogJSON
+
+	// AccessLogLogfmt writes each request as key=value pairs.
+	AccessLogLogfmt
+)
+
+// AccessLog writes a line for each HTTP request to a logger.
+type AccessLog struct {
+	logger *Logger
+
+	mutex  sync.RWMutex
+	format AccessLogFormat
+}
+
+// NewAccessLog returns an AccessLog that writes to the logger. The logger is usually a dedicated
+// logger with a MessageEncoder so that the access lines are written as they are.
+func NewAccessLog(logger *Logger, format AccessLogFormat) *AccessLog {
+	return &AccessLog{logger: logger, format: format}
+}
+
+// Logger returns the logger that the access log writes to.
+func (accessLog *AccessLog) Logger() *Logger {
+	return accessLog.logger
+}
+
+// SetFormat sets the format of the access lines.
+func (accessLog *AccessLog) SetFormat(format AccessLogFormat) {
+	accessLog.mutex.Lock()
+	defer accessLog.mutex.Unlock()
+	accessLog.format = format
+}
+
+// Format returns the format of the access lines.
+func (accessLog *AccessLog) Format() AccessLogFormat {
+	accessLog.mutex.RLock()
+	defer accessLog.mutex.RUnlock()
+	return accessLog.format
+}
+
+var defaultAccessLog = newDefaultAccessLog()
+
+func newDefaultAccessLog() *AccessLog {
+	logger := NewLogger()
+	logger.SetEncoder(MessageEncoder{})
+	return NewAccessLog(logger, AccessLogCombined)
+}
+
+// DefaultAccessLog returns the AccessLog used by AccessLogMiddleware. It writes combined log lines
+// to its own Logger, which writes to Stderr until its filename is set:
+//
+//	log.DefaultAccessLog().Logger().SetFilename("~/logs/access.log")
+func DefaultAccessLog() *AccessLog {
+	return defaultAccessLog
+}
+
+// AccessLogMiddleware returns a handler that calls next and writes an access line for each request
+// to the DefaultAccessLog.
+func AccessLogMiddleware(next http.Handler) http.Handler {
+	return defaultAccessLog.Middleware(next)
+}
+
+// Middleware returns a handler that calls next and writes an access line for each request.
+func (accessLog *AccessLog) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		recorder := &responseRecorder{ResponseWriter: writer}
+		start := time.Now()
+		defer func() {
+			reason := recover()
+			if reason != nil {
+				//  Log the crash as a server error and let RecoveryMiddleware or net/http handle it --
+				recorder.status = http.StatusInternalServerError
+			}
+			accessLog.write(request, recorder, start, time.Since(start))
+			if reason != nil {
+				panic(reason)
+			}
+		}()
+		next.ServeHTTP(recorder, request)
+	})
+}
+
+// write formats and logs the access line for the request.
+func (accessLog *AccessLog) write(request *http.Request, recorder *responseRecorder, start time.Time, duration time.Duration) {
+	status := recorder.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	user := "-"
+	if request.URL.User != nil && request.URL.User.Username() != "" {
+		user = request.URL.User.Username()
+	} else if username, _, ok := request.BasicAuth(); ok && username != "" {
+		user = username
+	}
+	clientIP := IPAddressFromHTTPRequest(request)
+
+	var line string
+	switch accessLog.Format() {
+	case AccessLogJSON:
+		var buffer bytes.Buffer
+		writeField := func(key string, value interface{}) {
+			if buffer.Len() == 0 {
+				buffer.WriteByte('{')
+			} else {
+				buffer.WriteByte(',')
+			}
+			writeJSONValue(&buffer, key)
+			buffer.WriteByte(':')
+			writeJSONValue(&buffer, value)
+		}
+		writeField("time", start.Format(time.RFC3339Nano))
+		writeField("client_ip", clientIP)
+		writeField("user", user)
+		writeField("method", request.Method)
+		writeField("path", request.RequestURI)
+		writeField("proto", request.Proto)
+		writeField("status", status)
+		writeField("bytes", recorder.bytes)
+		writeField("duration_ms", float64(duration.Microseconds())/1000)
+		writeField("referer", request.Referer())
+		writeField("user_agent", request.UserAgent())
+		if id := RequestIDFromContext(request.Context()); id != "" {
+			writeField("request_id", id)
+		}
+		buffer.WriteByte('}')
+		line = buffer.String()
+
+	case AccessLogLogfmt:
+		fields := []Field{
+			{"time", start.Format(time.RFC3339Nano)},
+			{"client_ip", clientIP},
+			{"user", user},
+			{"method", request.Method},
+			{"path", request.RequestURI},
+			{"proto", request.Proto},
+			{"status", status},
+			{"bytes", recorder.bytes},
+			{"duration", duration},
+			{"referer", request.Referer()},
+			{"user_agent", request.UserAgent()},
+		}
+		if id := RequestIDFromContext(request.Context()); id != "" {
+			fields = append(fields, Field{"request_id", id})
+		}
+		line = formatFields(fields)
+
+	default:
+		size := "-"
+		if recorder.bytes > 0 {
+			size = strconv.FormatInt(recorder.bytes, 10)
+		}
+		line = fmt.Sprintf(`%s - %s [%s] "%s %s %s" %d %s "%s" "%s"`,
+			escapeAccessLogItem(clientIP),
+			escapeAccessLogItem(user),
+			start.Format("02/Jan/2006:15:04:05 -0700"),
+			escapeAccessLogItem(request.Method),
+			escapeAccessLogItem(request.RequestURI),
+			escapeAccessLogItem(request.Proto),
+			status,
+			size,
+			escapeAccessLogItem(request.Referer()),
+			escapeAccessLogItem(request.UserAgent()),
+		)
+	}
+
+	level := LevelInfo
+	if status >= 500 {
+		level = LevelError
+	}
+	accessLog.logger.logFields(level, 2, line, nil)
+}
+
+// escapeAccessLogItem escapes a request value for a combined log line the way Apache does, so that
+// a client can't end a quoted value or forge a line: quotes and backslashes are escaped with a
+// backslash and other bytes that aren't printable ASCII are written as C escapes or \xHH.
+func escapeAccessLogItem(s string) string {
+	var builder strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '"' || c == '\\':
+			builder.WriteByte('\\')
+			builder.WriteByte(c)
+		case c == '\b':
+			builder.WriteString(`\b`)
+		case c == '\n':
+			builder.WriteString(`\n`)
+		case c == '\r':
+			builder.WriteString(`\r`)
+		case c == '\t':
+			builder.WriteString(`\t`)
+		case c == '\v':
+			builder.WriteString(`\v`)
+		case c < 0x20 || c >= 0x7f:
+			fmt.Fprintf(&builder, `\x%02x`, c)
+		default:
+			builder.WriteByte(c)
+		}
+	}
+	return builder.String()
+}
+
+// RecoveryMiddleware returns a handler that calls next and recovers if it panics. The panic and its
+// stack are logged with LogStackWithError to the request context's logger, and the client gets a
+// 500 Internal Server Error if the response hasn't been started.
+func RecoveryMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		recorder := &responseRecorder{ResponseWriter: writer}
+		defer func() {
+			reason := recover()
+			if reason == nil {
+				return
+			}
+			if reason == http.ErrAbortHandler {
+				panic(reason)
+			}
+			FromContext(request.Context()).LogStackWithError(reason)
+			if recorder.status == 0 {
+				http.Error(recorder, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			}
+		}()
+		next.ServeHTTP(recorder, request)
+	})
+}
+
+// responseRecorder records the status and size of a response.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (recorder *responseRecorder) WriteHeader(status int) {
+	if recorder.status == 0 {
+		recorder.status = status
+	}
+	recorder.ResponseWriter.WriteHeader(status)
+}
+
+func (recorder *responseRecorder) Write(b []byte) (int, error) {
+	if recorder.status == 0 {
+		recorder.status = http.StatusOK
+	}
+	n, error := recorder.ResponseWriter.Write(b)
+	recorder.bytes += int64(n)
+	return n, error
+}
+
+// Flush flushes the response if the underlying ResponseWriter can.
+func (recorder *responseRecorder) Flush() {
+	if flusher, ok := recorder.ResponseWriter.(http.Flusher); ok {
+		if recorder.status == 0 {
+			recorder.status = http.StatusOK
+		}
+		flusher.Flush()
+	}
+}
+
+// Hijack lets the handler take over the connection, as for a WebSocket upgrade, if the underlying
+// ResponseWriter can.
+func (recorder *responseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := recorder.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	if recorder.status == 0 {
+		recorder.status = http.StatusSwitchingProtocols
+	}
+	return hijacker.Hijack()
+}
+
+// Unwrap returns the underlying ResponseWriter for http.ResponseController.
+func (recorder *responseRecorder) Unwrap() http.ResponseWriter {
+	return recorder.ResponseWriter
+}
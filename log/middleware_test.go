@@ -0,0 +1,163 @@
+/**
+@file          middleware_test.go
+@package       log
+@brief         Test the access log and recovery middleware.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestAccessLogMiddleware(t *testing.T) {
+	logger, ring := newTestRingLogger(t)
+	logger.SetEncoder(MessageEncoder{})
+	accessLog := NewAccessLog(logger, AccessLogCombined)
+	handler := accessLog.Middleware(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path == "/missing" {
+			http.NotFound(writer, request)
+			return
+		}
+		writer.Write([]byte("Hello."))
+	}))
+	serve := func(path string) string {
+		request := httptest.NewRequest(http.MethodGet, path, nil)
+		request.RemoteAddr = "192.168.1.20:53211"
+		request.Header.Set("User-Agent", "test/1.0")
+		request.Header.Set("Referer", "https://example.com/")
+		request = request.WithContext(ContextWithRequestID(context.Background(), "r-7"))
+		handler.ServeHTTP(httptest.NewRecorder(), request)
+		lines := ring.Lines(RingQuery{Limit: 1})
+		return strings.TrimSpace(string(lines[0]))
+	}
+
+	r := regexp.MustCompile(`^192\.168\.1\.20 - - \[\d\d/\w{3}/\d{4}:\d\d:\d\d:\d\d [-+]\d{4}\] ` +
+		`"GET /hello\?a=1 HTTP/1\.1" 200 6 "https://example.com/" "test/1\.0"$`)
+	if line := serve("/hello?a=1"); !r.MatchString(line) {
+		t.Errorf("Unexpected combined line '%s'.", line)
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "/hello", nil)
+	request.RemoteAddr = "192.168.1.20:53211"
+	request.Header.Set("User-Agent", "evil\" \"x\\\r\n\x01")
+	handler.ServeHTTP(httptest.NewRecorder(), request)
+	if line := strings.TrimSpace(string(ring.Lines(RingQuery{Limit: 1})[0])); !strings.HasSuffix(line, ` 200 6 "" "evil\" \"x\\\r\n\x01"`) {
+		t.Errorf("Expected an escaped user agent in '%s'.", line)
+	}
+
+	accessLog.SetFormat(AccessLogJSON)
+	var values map[string]interface{}
+	if error := json.Unmarshal([]byte(serve("/missing")), &values); error != nil {
+		t.Fatal(error)
+	}
+	if values["status"] != 404.0 || values["client_ip"] != "192.168.1.20" || values["request_id"] != "r-7" ||
+		values["path"] != "/missing" {
+		t.Errorf("Unexpected JSON line %v.", values)
+	}
+
+	accessLog.SetFormat(AccessLogLogfmt)
+	line := serve("/hello")
+	if !strings.Contains(line, " client_ip=192.168.1.20 user=- method=GET path=/hello proto=HTTP/1.1 status=200 bytes=6 ") ||
+		!strings.HasSuffix(line, ` user_agent=test/1.0 request_id=r-7`) {
+		t.Errorf("Unexpected logfmt line '%s'.", line)
+	}
+}
+
+func TestRecoveryMiddleware(t *testing.T) {
+	logger, ring := newTestRingLogger(t)
+	handler := RecoveryMiddleware(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		panic("nil map")
+	}))
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request = request.WithContext(WithContext(request.Context(), logger))
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500, found %d.", recorder.Code)
+	}
+	entries := ring.Entries(RingQuery{MinLevel: LevelError})
+	if len(entries) != 2 || entries[0].Message != "'nil map'." || !strings.Contains(entries[1].Message, "panic") {
+		t.Errorf("Unexpected entries %+v.", entries)
+	}
+}
+
+func TestAccessLogPanic(t *testing.T) {
+	logger, ring := newTestRingLogger(t)
+	logger.SetEncoder(MessageEncoder{})
+	accessLog := NewAccessLog(logger, AccessLogLogfmt)
+	handler := RecoveryMiddleware(accessLog.Middleware(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		panic("crash")
+	})))
+	request := httptest.NewRequest(http.MethodGet, "/crash", nil)
+	request = request.WithContext(WithContext(request.Context(), logger))
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500, found %d.", recorder.Code)
+	}
+	entries := ring.Entries(RingQuery{Contains: "path=/crash"})
+	if len(entries) != 1 || entries[0].Level != LevelError || !strings.Contains(entries[0].Message, " status=500 ") {
+		t.Errorf("Unexpected access entries %+v.", entries)
+	}
+}
+
+func TestAccessLogHijack(t *testing.T) {
+	logger, _ := newTestRingLogger(t)
+	handler := NewAccessLog(logger, AccessLogCombined).Middleware(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		hijacker, ok := writer.(http.Hijacker)
+		if !ok {
+			http.Error(writer, "Can't hijack.", http.StatusInternalServerError)
+			return
+		}
+		connection, buffer, error := hijacker.Hijack()
+		if error != nil {
+			http.Error(writer, error.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer connection.Close()
+		buffer.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n\r\n")
+		buffer.Flush()
+	}))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	request, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	request.Header.Set("Connection", "Upgrade")
+	request.Header.Set("Upgrade", "websocket")
+	response, error := http.DefaultClient.Do(request)
+	if error != nil {
+		t.Fatal(error)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusSwitchingProtocols {
+		t.Errorf("Expected status 101, found %d.", response.StatusCode)
+	}
+}
+
+func TestIPAddressFromHTTPRequest(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	for remote, ip := range map[string]string{
+		"10.0.0.1:1234": "10.0.0.1",
+		"[::1]:8080":    "::1",
+		"10.0.0.2":      "10.0.0.2",
+	} {
+		request.RemoteAddr = remote
+		if s := IPAddressFromHTTPRequest(request); s != ip {
+			t.Errorf("Expected %s for %s but found %s.", ip, remote, s)
+		}
+	}
+	request.Header.Set("X-Forwarded-For", " 203.0.113.9, 10.0.0.1")
+	if s := IPAddressFromHTTPRequest(request); s != "203.0.113.9" {
+		t.Errorf("Unexpected forwarded address %s.", s)
+	}
+}
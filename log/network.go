@@ -0,0 +1,380 @@
+/**
+@file          network.go
+@package       log
+@brief         A sink that forwards log lines to a network collector, spooling to disk when it's down.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	networkSinkQueueSize = 4096
+	networkSinkBatchSize = 64 * 1024
+
+	// DefaultSpoolSize is the default maximum size in bytes of a NetworkSink's spool file.
+	DefaultSpoolSize = 64 * 1024 * 1024
+
+	// DefaultRetryInterval is the default time between attempts to resend spooled log lines.
+	DefaultRetryInterval = time.Second * 5
+)
+
+// ErrSinkClosed is returned when writing to a sink that has been closed.
+var ErrSinkClosed = errors.New("sink closed")
+
+// NetworkSink is a Sink that forwards newline delimited log lines to a collector over TCP, TLS, or
+// HTTP. Lines that can't be delivered are spooled to a file and are replayed in order once the
+// collector is back.
+type NetworkSink struct {
+	network       string
+	address       string
+	spoolFilename string
+
+	mutex         sync.RWMutex
+	tlsConfig     *tls.Config
+	maxSpoolSize  int64
+	retryInterval time.Duration
+	timeout       time.Duration
+	closed        bool
+
+	lines   chan []byte
+	flushes chan chan error
+	done    chan struct{}
+	dropped atomic.Uint64
+
+	//  Owned by the send goroutine --
+	conn     net.Conn
+	spooling bool
+}
+
+// NewNetworkSink returns a sink that sends log lines to a collector. The network is "tcp" or "tls"
+// with a host:port address, or "http" with a URL address that receives the lines in POST requests.
+// Undelivered lines are spooled to spoolFilename, which is usually next to the log file. If
+// spoolFilename is empty undelivered lines are dropped.
+func NewNetworkSink(network, address, spoolFilename string) (*NetworkSink, error) {
+	switch network {
+	case "tcp", "tls", "http":
+	default:
+		return nil, fmt.Errorf("unknown network '%s'", network)
+	}
+	if spoolFilename != "" {
+		spoolFilename = absolutePath(spoolFilename)
+		if error := os.MkdirAll(filepath.Dir(spoolFilename), 0700); error != nil {
+			return nil, error
+		}
+	}
+	sink := &NetworkSink{
+		network:       network,
+		address:       address,
+		spoolFilename: spoolFilename,
+		maxSpoolSize:  DefaultSpoolSize,
+		retryInterval: DefaultRetryInterval,
+		timeout:       time.Second * 10,
+		lines:         make(chan []byte, networkSinkQueueSize),
+		flushes:       make(chan chan error),
+		done:          make(chan struct{}),
+	}
+	if info, error := os.Stat(spoolFilename); error == nil && info.Size() > 0 {
+		//  Replay lines spooled by an earlier run --
+		sink.spooling = true
+	}
+	go sink.run()
+	return sink, nil
+}
+
+// SetTLSConfig sets the TLS configuration used by a "tls" sink.
+func (sink *NetworkSink) SetTLSConfig(config *tls.Config) {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	sink.tlsConfig = config
+}
+
+// SetMaxSpoolSize sets the maximum size in bytes of the spool file. Lines that don't fit are dropped.
+func (sink *NetworkSink) SetMaxSpoolSize(size int64) {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	sink.maxSpoolSize = size
+}
+
+// SetRetryInterval sets the time between attempts to resend spooled lines.
+func (sink *NetworkSink) SetRetryInterval(interval time.Duration) {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	sink.retryInterval = interval
+}
+
+// SetTimeout sets the time allowed to connect and send lines to the collector.
+func (sink *NetworkSink) SetTimeout(timeout time.Duration) {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	sink.timeout = timeout
+}
+
+// Dropped returns the number of lines dropped because the queue or spool was full.
+func (sink *NetworkSink) Dropped() uint64 {
+	return sink.dropped.Load()
+}
+
+// Spooling returns true if there are lines in the spool file waiting to be delivered.
+func (sink *NetworkSink) Spooling() bool {
+	info, error := os.Stat(sink.spoolFilename)
+	return error == nil && info.Size() > 0
+}
+
+// WriteEntry queues the line to be sent. It doesn't wait for the line to be delivered.
+func (sink *NetworkSink) WriteEntry(_ *Entry, line []byte) error {
+	sink.mutex.RLock()
+	defer sink.mutex.RUnlock()
+	if sink.closed {
+		return ErrSinkClosed
+	}
+	select {
+	case sink.lines <- line:
+	default:
+		sink.dropped.Add(1)
+	}
+	return nil
+}
+
+// Flush sends the queued lines and tries to deliver the spooled lines. It returns an error if the
+// collector couldn't be reached.
+func (sink *NetworkSink) Flush() error {
+	reply := make(chan error)
+	select {
+	case sink.flushes <- reply:
+		return <-reply
+	case <-sink.done:
+		return ErrSinkClosed
+	}
+}
+
+// Close sends the queued lines and stops the sink. Lines that can't be delivered stay in the spool
+// file and are replayed by the next NetworkSink that uses it.
+func (sink *NetworkSink) Close() error {
+	sink.mutex.Lock()
+	if sink.closed {
+		sink.mutex.Unlock()
+		return nil
+	}
+	sink.closed = true
+	close(sink.lines)
+	sink.mutex.Unlock()
+	<-sink.done
+	return nil
+}
+
+func (sink *NetworkSink) run() {
+	defer close(sink.done)
+
+	//  The retry timer is only re-armed after a replay attempt so that a steady stream of new lines
+	//  can't keep putting the replay off --
+
+	var retry *time.Timer
+	defer func() {
+		if retry != nil {
+			retry.Stop()
+		}
+	}()
+	for {
+		var retryChannel <-chan time.Time
+		if sink.spooling {
+			if retry == nil {
+				sink.mutex.RLock()
+				retry = time.NewTimer(sink.retryInterval)
+				sink.mutex.RUnlock()
+			}
+			retryChannel = retry.C
+		} else if retry != nil {
+			retry.Stop()
+			retry = nil
+		}
+		select {
+		case line, ok := <-sink.lines:
+			if !ok {
+				sink.replay()
+				sink.disconnect()
+				return
+			}
+			sink.send(sink.batch(line))
+		case reply := <-sink.flushes:
+			for sent := false; !sent; {
+				select {
+				case line, ok := <-sink.lines:
+					if ok {
+						sink.send(sink.batch(line))
+					} else {
+						sent = true
+					}
+				default:
+					sent = true
+				}
+			}
+			reply <- sink.replay()
+			if retry != nil {
+				retry.Stop()
+				retry = nil
+			}
+		case <-retryChannel:
+			retry = nil
+			sink.replay()
+		}
+	}
+}
+
+// batch appends the lines waiting in the queue to the line, up to the batch size.
+func (sink *NetworkSink) batch(line []byte) []byte {
+	batch := append([]byte(nil), line...)
+	for len(batch) < networkSinkBatchSize {
+		select {
+		case line, ok := <-sink.lines:
+			if !ok {
+				return batch
+			}
+			batch = append(batch, line...)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+// send delivers the batch, or spools it if the collector is down or earlier lines are still spooled.
+func (sink *NetworkSink) send(batch []byte) {
+	if sink.spooling || sink.deliver(batch) != nil {
+		sink.spool(batch)
+	}
+}
+
+// spool appends the batch to the spool file.
+func (sink *NetworkSink) spool(batch []byte) {
+	sink.mutex.RLock()
+	maxSpoolSize := sink.maxSpoolSize
+	sink.mutex.RUnlock()
+	if sink.spoolFilename == "" {
+		sink.dropped.Add(uint64(bytes.Count(batch, []byte{'\n'})))
+		return
+	}
+	if info, error := os.Stat(sink.spoolFilename); error == nil && info.Size()+int64(len(batch)) > maxSpoolSize {
+		sink.dropped.Add(uint64(bytes.Count(batch, []byte{'\n'})))
+		return
+	}
+	file, error := os.OpenFile(sink.spoolFilename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if error != nil {
+		sink.dropped.Add(uint64(bytes.Count(batch, []byte{'\n'})))
+		return
+	}
+	defer file.Close()
+	file.Write(batch)
+	sink.spooling = true
+}
+
+// replay delivers the spooled lines in order. Lines that can't be delivered are left in the spool.
+func (sink *NetworkSink) replay() error {
+	if !sink.spooling {
+		return nil
+	}
+	data, error := os.ReadFile(sink.spoolFilename)
+	if error != nil && !os.IsNotExist(error) {
+		return error
+	}
+	for len(data) > 0 {
+		n := len(data)
+		if n > networkSinkBatchSize {
+			n = networkSinkBatchSize
+			if i := bytes.LastIndexByte(data[:n], '\n'); i >= 0 {
+				n = i + 1
+			}
+		}
+		if error = sink.deliver(data[:n]); error != nil {
+			temp := sink.spoolFilename + ".tmp"
+			if os.WriteFile(temp, data, 0600) == nil {
+				os.Rename(temp, sink.spoolFilename)
+			}
+			return error
+		}
+		data = data[n:]
+	}
+	os.Remove(sink.spoolFilename)
+	sink.spooling = false
+	return nil
+}
+
+// deliver sends the batch to the collector.
+func (sink *NetworkSink) deliver(batch []byte) error {
+	sink.mutex.RLock()
+	timeout := sink.timeout
+	tlsConfig := sink.tlsConfig
+	sink.mutex.RUnlock()
+
+	if sink.network == "http" {
+		client := http.Client{Timeout: timeout}
+		response, error := client.Post(sink.address, "application/x-ndjson", bytes.NewReader(batch))
+		if error != nil {
+			return error
+		}
+		io.Copy(io.Discard, response.Body)
+		response.Body.Close()
+		if response.StatusCode < 200 || response.StatusCode > 299 {
+			return fmt.Errorf("collector returned status %s", response.Status)
+		}
+		return nil
+	}
+
+	if sink.conn != nil && !sink.connected() {
+		sink.disconnect()
+	}
+	if sink.conn == nil {
+		var error error
+		dialer := &net.Dialer{Timeout: timeout}
+		if sink.network == "tls" {
+			sink.conn, error = tls.DialWithDialer(dialer, "tcp", sink.address, tlsConfig)
+		} else {
+			sink.conn, error = dialer.Dial("tcp", sink.address)
+		}
+		if error != nil {
+			sink.conn = nil
+			return error
+		}
+	}
+	sink.conn.SetWriteDeadline(time.Now().Add(timeout))
+	if _, error := sink.conn.Write(batch); error != nil {
+		sink.disconnect()
+		return error
+	}
+	return nil
+}
+
+// connected returns false if the collector has closed the connection. The collector doesn't send
+// anything, so a read that doesn't time out means the connection is closed. A deadline that has
+// already passed fails without reading, so the read waits briefly.
+func (sink *NetworkSink) connected() bool {
+	var b [1]byte
+	sink.conn.SetReadDeadline(time.Now().Add(time.Millisecond))
+	_, error := sink.conn.Read(b[:])
+	sink.conn.SetReadDeadline(time.Time{})
+	var netError net.Error
+	return errors.As(error, &netError) && netError.Timeout()
+}
+
+func (sink *NetworkSink) disconnect() {
+	if sink.conn != nil {
+		sink.conn.Close()
+		sink.conn = nil
+	}
+}
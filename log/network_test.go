@@ -0,0 +1,215 @@
+/**
+@file          network_test.go
+@package       log
+@brief         Test the network log sink against a local collector.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testCollector is a TCP log collector that records the lines it receives.
+type testCollector struct {
+	listener net.Listener
+	mutex    sync.Mutex
+	conns    []net.Conn
+	lines    []string
+}
+
+func startTestCollector(t *testing.T, address string) *testCollector {
+	listener, error := net.Listen("tcp", address)
+	if error != nil {
+		t.Fatal(error)
+	}
+	collector := &testCollector{listener: listener}
+	go func() {
+		for {
+			conn, error := listener.Accept()
+			if error != nil {
+				return
+			}
+			collector.mutex.Lock()
+			collector.conns = append(collector.conns, conn)
+			collector.mutex.Unlock()
+			go func() {
+				scanner := bufio.NewScanner(conn)
+				for scanner.Scan() {
+					collector.mutex.Lock()
+					collector.lines = append(collector.lines, scanner.Text())
+					collector.mutex.Unlock()
+				}
+			}()
+		}
+	}()
+	return collector
+}
+
+func (collector *testCollector) stop() {
+	collector.listener.Close()
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+	for _, conn := range collector.conns {
+		conn.Close()
+	}
+}
+
+func (collector *testCollector) waitForLines(count int) []string {
+	for i := 0; i < 200; i++ {
+		collector.mutex.Lock()
+		n := len(collector.lines)
+		collector.mutex.Unlock()
+		if n >= count {
+			break
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+	return append([]string(nil), collector.lines...)
+}
+
+func messages(lines []string) string {
+	var result []string
+	for _, line := range lines {
+		result = append(result, line[strings.LastIndex(line, ": ")+2:])
+	}
+	return strings.Join(result, " ")
+}
+
+func TestNetworkSinkSpool(t *testing.T) {
+	dir := t.TempDir()
+	collector := startTestCollector(t, "127.0.0.1:0")
+	address := collector.listener.Addr().String()
+
+	sink, error := NewNetworkSink("tcp", address, filepath.Join(dir, "app.log.spool"))
+	if error != nil {
+		t.Fatal(error)
+	}
+	sink.SetRetryInterval(time.Hour)
+	logger := NewLogger()
+	logger.SetFilename(filepath.Join(dir, "app.log"))
+	logger.AddSink(sink)
+
+	logger.Infof("One.")
+	logger.FlushMessages()
+	if s := messages(collector.waitForLines(1)); s != "One." {
+		t.Errorf("Expected 'One.' but found '%s'.", s)
+	}
+
+	//  Collector is down --
+
+	collector.stop()
+	time.Sleep(time.Millisecond * 50)
+	logger.Infof("Two.")
+	logger.Infof("Three.")
+	logger.FlushMessages()
+	if !sink.Spooling() {
+		t.Errorf("Expected spooled lines.")
+	}
+
+	//  Collector is back --
+
+	collector = startTestCollector(t, address)
+	defer collector.stop()
+	logger.Infof("Four.")
+	logger.FlushMessages()
+	if s := messages(collector.waitForLines(3)); s != "Two. Three. Four." {
+		t.Errorf("Expected 'Two. Three. Four.' but found '%s'.", s)
+	}
+	if sink.Spooling() {
+		t.Errorf("Expected an empty spool.")
+	}
+	logger.RemoveSink(sink)
+	sink.Close()
+	logger.SetFilename("")
+}
+
+func TestNetworkSinkHTTP(t *testing.T) {
+	var (
+		mutex sync.Mutex
+		body  string
+		down  atomic.Bool
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if down.Load() {
+			writer.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		b, _ := io.ReadAll(request.Body)
+		mutex.Lock()
+		body += string(b)
+		mutex.Unlock()
+	}))
+	defer server.Close()
+
+	sink, _ := NewNetworkSink("http", server.URL, filepath.Join(t.TempDir(), "http.spool"))
+	defer sink.Close()
+	entry := testEntry()
+	down.Store(true)
+	sink.WriteEntry(entry, []byte("first\n"))
+	if sink.Flush() == nil {
+		t.Errorf("Expected an error while the collector is down.")
+	}
+	down.Store(false)
+	sink.WriteEntry(entry, []byte("second\n"))
+	if error := sink.Flush(); error != nil {
+		t.Errorf("Unexpected error %v.", error)
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	if body != "first\nsecond\n" {
+		t.Errorf("Unexpected body '%s'.", body)
+	}
+}
+
+func TestNetworkSinkReplayUnderLoad(t *testing.T) {
+	collector := startTestCollector(t, "127.0.0.1:0")
+	address := collector.listener.Addr().String()
+	collector.stop()
+
+	sink, error := NewNetworkSink("tcp", address, filepath.Join(t.TempDir(), "load.spool"))
+	if error != nil {
+		t.Fatal(error)
+	}
+	defer sink.Close()
+	sink.SetRetryInterval(time.Millisecond * 100)
+	sink.SetTimeout(time.Millisecond * 100)
+
+	//  Lines keep arriving faster than the retry interval during and after an outage --
+
+	entry := testEntry()
+	for i := 0; i < 20; i++ {
+		sink.WriteEntry(entry, []byte("Down.\n"))
+		time.Sleep(time.Millisecond * 20)
+	}
+	collector = startTestCollector(t, address)
+	defer collector.stop()
+	for i := 0; i < 100; i++ {
+		sink.WriteEntry(entry, []byte("Up.\n"))
+		time.Sleep(time.Millisecond * 20)
+	}
+	collector.mutex.Lock()
+	received := len(collector.lines)
+	collector.mutex.Unlock()
+	if received < 100 {
+		t.Errorf("Expected the spool to be replayed while lines were arriving, found %d lines.", received)
+	}
+	if lines := collector.waitForLines(120); len(lines) != 120 || sink.Spooling() {
+		t.Errorf("Expected 120 lines and an empty spool, found %d lines, spooling %v.", len(lines), sink.Spooling())
+	}
+}
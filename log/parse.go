@@ -0,0 +1,270 @@
+/**
+@file          parse.go
+@package       log
+@brief         Parses log lines and reads log entries from a log file and its rotated archives.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// textLinePattern matches a line written by the TextEncoder.
+var textLinePattern = regexp.MustCompile(
+	`^(\S+) +(\S*):(\d+) +(Inval|All|Debug|Info|Start|Exit|Warn|Error|None): (.*)$`,
+)
+
+// textLevelNames maps the level names written by the TextEncoder to levels.
+var textLevelNames = map[string]Level{
+	"Inval": LevelInvalid,
+	"All":   LevelAll,
+	"Debug": LevelDebug,
+	"Info":  LevelInfo,
+	"Start": LevelStart,
+	"Exit":  LevelExit,
+	"Warn":  LevelWarning,
+	"Error": LevelError,
+	"None":  LevelNone,
+}
+
+// ErrInvalidLine is returned by ParseLine for a line that isn't a log line.
+var ErrInvalidLine = errors.New("invalid log line")
+
+// ParseLine parses a line written by the TextEncoder or the JSONEncoder back into an entry. The
+// entry's File is the caller as it's shown in the log, and its PC is zero. Since fields can't be
+// told apart from the message in a text line, they're left as part of the message.
+func ParseLine(line string) (*Entry, error) {
+	line = strings.TrimRight(line, "\r\n")
+	if strings.HasPrefix(line, "{") {
+		return parseJSONLine(line)
+	}
+	match := textLinePattern.FindStringSubmatch(line)
+	if match == nil {
+		return nil, ErrInvalidLine
+	}
+	t, error := time.Parse(time.RFC3339, match[1])
+	if error != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidLine, error)
+	}
+	lineNumber, _ := strconv.Atoi(match[3])
+	return &Entry{
+		Time:    t,
+		Level:   textLevelNames[match[4]],
+		File:    match[2],
+		Line:    lineNumber,
+		Message: match[5],
+	}, nil
+}
+
+// parseJSONLine parses a line written by the JSONEncoder.
+func parseJSONLine(line string) (*Entry, error) {
+	decoder := json.NewDecoder(strings.NewReader(line))
+	decoder.UseNumber()
+	var values map[string]interface{}
+	if error := decoder.Decode(&values); error != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidLine, error)
+	}
+	entry := &Entry{Level: LevelInvalid}
+	if s, ok := values["time"].(string); ok {
+		entry.Time, _ = time.Parse(time.RFC3339Nano, s)
+	}
+	if s, ok := values["level"].(string); ok {
+		entry.Level = LevelFromString(s)
+	}
+	if s, ok := values["caller"].(string); ok {
+		entry.File = s
+		if i := strings.LastIndexByte(s, ':'); i >= 0 {
+			entry.File = s[:i]
+			entry.Line, _ = strconv.Atoi(s[i+1:])
+		}
+	}
+	entry.Message, _ = values["msg"].(string)
+	if entry.Time.IsZero() || entry.Level == LevelInvalid {
+		return nil, ErrInvalidLine
+	}
+
+	//  Keep the fields in the order they were written --
+
+	decoder = json.NewDecoder(strings.NewReader(line))
+	decoder.UseNumber()
+	decoder.Token()
+	for decoder.More() {
+		token, error := decoder.Token()
+		if error != nil {
+			break
+		}
+		key, _ := token.(string)
+		var value interface{}
+		if decoder.Decode(&value) != nil {
+			break
+		}
+		switch key {
+		case "time", "level", "caller", "msg":
+		default:
+			entry.Fields = append(entry.Fields, Field{Key: key, Value: value})
+		}
+	}
+	return entry, nil
+}
+
+// LogFiles returns the rotated archives of the log file, oldest first, followed by the log file
+// itself if it exists. Archives that are being compressed are listed once.
+func LogFiles(filename string) ([]string, error) {
+	filename = absolutePath(filename)
+	logfiles, error := rotatedLogFiles(filename)
+	if error != nil {
+		return nil, error
+	}
+	names := make(map[string]bool, len(logfiles))
+	for _, logfile := range logfiles {
+		names[logfile] = true
+	}
+	archives := make([]string, 0, len(logfiles)+1)
+	for _, logfile := range logfiles {
+		if names[logfile+compressSuffix] {
+			continue
+		}
+		archives = append(archives, logfile)
+	}
+	archives = sortLogArchives(archives)
+	if fileExists(filename) {
+		archives = append(archives, filename)
+	}
+	return archives, nil
+}
+
+// Reader reads the log entries of a log file and its rotated archives, including compressed
+// archives, in chronological order. Lines that aren't log lines are skipped.
+//
+//	reader := log.NewReader(filename)
+//	defer reader.Close()
+//	for reader.Next() {
+//	    entry := reader.Entry()
+//	}
+//	if reader.Err() != nil { ... }
+type Reader struct {
+	filenames []string
+	filename  string
+	file      *os.File
+	gzip      *gzip.Reader
+	scanner   *bufio.Scanner
+	line      string
+	entry     *Entry
+	error     error
+}
+
+// NewReader returns a Reader for the log file and its rotated archives.
+func NewReader(filename string) *Reader {
+	filenames, error := LogFiles(filename)
+	return &Reader{filenames: filenames, error: error}
+}
+
+// NewFileReader returns a Reader for the files, read in the order given.
+func NewFileReader(filenames ...string) *Reader {
+	return &Reader{filenames: filenames}
+}
+
+// Next advances to the next entry. It returns false at the end of the files or on an error.
+func (reader *Reader) Next() bool {
+	for reader.error == nil {
+		if reader.scanner == nil {
+			if len(reader.filenames) == 0 {
+				return false
+			}
+			if reader.error = reader.open(reader.filenames[0]); reader.error != nil {
+				return false
+			}
+			reader.filenames = reader.filenames[1:]
+		}
+		if !reader.scanner.Scan() {
+			reader.error = reader.scanner.Err()
+			reader.closeFile()
+			continue
+		}
+		reader.line = reader.scanner.Text()
+		if entry, error := ParseLine(reader.line); error == nil {
+			reader.entry = entry
+			return true
+		}
+	}
+	return false
+}
+
+// Entry returns the current entry.
+func (reader *Reader) Entry() *Entry {
+	return reader.entry
+}
+
+// Line returns the text of the current entry's line.
+func (reader *Reader) Line() string {
+	return reader.line
+}
+
+// Filename returns the name of the file that's being read.
+func (reader *Reader) Filename() string {
+	return reader.filename
+}
+
+// Err returns the error that stopped the reader, if any.
+func (reader *Reader) Err() error {
+	return reader.error
+}
+
+// Close closes the file being read.
+func (reader *Reader) Close() error {
+	reader.filenames = nil
+	reader.closeFile()
+	return nil
+}
+
+// open opens the file, decompressing it if it's a compressed archive.
+func (reader *Reader) open(filename string) error {
+	file, error := os.Open(filename)
+	if os.IsNotExist(error) && !strings.HasSuffix(filename, compressSuffix) {
+		//  The archive was compressed after it was listed --
+		filename += compressSuffix
+		file, error = os.Open(filename)
+	}
+	if error != nil {
+		return error
+	}
+	var r io.Reader = file
+	if strings.HasSuffix(filename, compressSuffix) {
+		if reader.gzip, error = gzip.NewReader(file); error != nil {
+			file.Close()
+			return fmt.Errorf("can't read '%s': %w", filename, error)
+		}
+		r = reader.gzip
+	}
+	reader.filename = filename
+	reader.file = file
+	reader.scanner = bufio.NewScanner(r)
+	reader.scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	return nil
+}
+
+func (reader *Reader) closeFile() {
+	if reader.gzip != nil {
+		reader.gzip.Close()
+		reader.gzip = nil
+	}
+	if reader.file != nil {
+		reader.file.Close()
+		reader.file = nil
+	}
+	reader.scanner = nil
+}
@@ -0,0 +1,106 @@
+/**
+@file          parse_test.go
+@package       log
+@brief         Test parsing log lines and reading rotated log archives.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseLine(t *testing.T) {
+	entry := testEntry()
+	parsed, error := ParseLine(string(TextEncoder{}.Encode(entry)))
+	if error != nil {
+		t.Fatal(error)
+	}
+	if !parsed.Time.Equal(entry.Time) || parsed.Level != LevelWarning || parsed.File != "scanner/scanner.go" ||
+		parsed.Line != 42 || parsed.Message != "Two|lines. user=7 err=failed" {
+		t.Errorf("Unexpected entry %+v.", parsed)
+	}
+
+	parsed, error = ParseLine(string(JSONEncoder{}.Encode(entry)))
+	if error != nil {
+		t.Fatal(error)
+	}
+	if !parsed.Time.Equal(entry.Time) || parsed.Level != LevelWarning || parsed.File != "scanner/scanner.go" ||
+		parsed.Line != 42 || parsed.Message != "Two\nlines." || formatFields(parsed.Fields) != "user=7 err=failed" {
+		t.Errorf("Unexpected entry %+v.", parsed)
+	}
+
+	line := "2026-10-17T09:30:00-07:00 averyveryverylongfilenam/averyveryverylongfilename.g:12345 Error: Oops: x."
+	if parsed, error = ParseLine(line); error != nil || parsed.Line != 12345 || parsed.Message != "Oops: x." {
+		t.Errorf("Unexpected entry %+v %v.", parsed, error)
+	}
+	if _, error := ParseLine("Stack of 200 bytes: goroutine 1 [running]:"); error != ErrInvalidLine {
+		t.Errorf("Expected an invalid line error, found %v.", error)
+	}
+}
+
+func TestReader(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "reader.log")
+	logger := NewLogger()
+	logger.SetFilename(filename)
+	logger.SetRetentionCount(0)
+	for i := 0; i < 9; i++ {
+		logger.Infof("Message %d.", i)
+		if i%3 == 2 {
+			logger.SetCompressRotated(i == 2)
+			logger.Rotate()
+			logger.archiving.Wait()
+		}
+	}
+	logger.SetFilename("")
+
+	logfiles, _ := LogFiles(filename)
+	if len(logfiles) != 4 || !strings.HasSuffix(logfiles[0], compressSuffix) || logfiles[3] != filename {
+		t.Errorf("Unexpected log files %v.", logfiles)
+	}
+	var messages []string
+	reader := NewReader(filename)
+	defer reader.Close()
+	for reader.Next() {
+		if strings.HasPrefix(reader.Entry().Message, "Message") {
+			messages = append(messages, reader.Entry().Message)
+		}
+	}
+	if reader.Err() != nil {
+		t.Error(reader.Err())
+	}
+	var expected []string
+	for i := 0; i < 9; i++ {
+		expected = append(expected, fmt.Sprintf("Message %d.", i))
+	}
+	if strings.Join(messages, " ") != strings.Join(expected, " ") {
+		t.Errorf("Unexpected messages %v.", messages)
+	}
+}
+
+func TestLogFilesOtherLogs(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "app.log")
+	for _, name := range []string{"app.log", "app-access.log", "app-2026-10-17T09-30-00-07-00.log", "app-2026-10-17T09-30-00-07-00-1.log.gz"} {
+		os.WriteFile(filepath.Join(dir, name), []byte("2026-10-17T09:30:00-07:00 log/parse_test.go:1    Info: Message.\n"), 0600)
+	}
+	logfiles, error := LogFiles(filename)
+	if error != nil {
+		t.Fatal(error)
+	}
+	if len(logfiles) != 3 || logfiles[2] != filename {
+		t.Errorf("Unexpected log files %v.", logfiles)
+	}
+	for _, logfile := range logfiles {
+		if strings.HasSuffix(logfile, "app-access.log") {
+			t.Errorf("Another logger's file '%s' was included.", logfile)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+/**
+@file          redact.go
+@package       log
+@brief         Masks secrets and personal information in log messages before they're written.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// RedactedText replaces the redacted parts of a log message.
+const RedactedText = "[REDACTED]"
+
+// Built in redaction patterns.
+var (
+	// bearerPattern matches a bearer token, keeping the word "Bearer".
+	bearerPattern = regexp.MustCompile(`(?i)\b(bearer)\s+[A-Za-z0-9\-._~+/]+=*`)
+
+	// emailPattern matches an email address. It's util.ValidatedEmailAddress's `.+@.+\..+` pattern
+	// limited to the characters that can be part of an address so that it can be found in a message.
+	emailPattern = regexp.MustCompile(`[^\s@"'<>(),;:=]+@[^\s@"'<>(),;:=]+\.[A-Za-z]+`)
+
+	// cardPattern matches a run of 13 to 19 digits, which may be grouped with spaces or dashes.
+	cardPattern = regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`)
+)
+
+// DefaultSensitiveFields are the field keys that a new Redactor always masks.
+var DefaultSensitiveFields = []string{"password", "passwd", "secret", "token", "authorization", "api_key"}
+
+// sensitiveValue is a field value that's always masked.
+type sensitiveValue struct {
+	value interface{}
+}
+
+// String returns the masked value so that the value isn't written by any encoder.
+func (sensitiveValue) String() string { return RedactedText }
+
+// MarshalJSON returns the masked value.
+func (sensitiveValue) MarshalJSON() ([]byte, error) { return []byte(`"` + RedactedText + `"`), nil }
+
+// Sensitive marks a field value as sensitive so that it's masked in the log:
+//
+//	log.Info("Signed in.", "user", name, "password", log.Sensitive(password))
+func Sensitive(value interface{}) interface{} {
+	return sensitiveValue{value: value}
+}
+
+// redactRule replaces the text matched by a pattern.
+type redactRule struct {
+	name        string
+	pattern     *regexp.Regexp
+	replace     func(string) string
+	replacement string
+}
+
+// Redactor masks the text in log messages and fields that matches its patterns, and the values
+// of fields that are marked as sensitive. A Redactor is safe to change while it's in use.
+type Redactor struct {
+	mutex     sync.RWMutex
+	rules     []redactRule
+	sensitive map[string]bool
+}
+
+// NewRedactor returns a Redactor that masks bearer tokens, email addresses, card numbers, and the
+// DefaultSensitiveFields.
+func NewRedactor() *Redactor {
+	redactor := &Redactor{
+		rules: []redactRule{
+			{name: "bearer", pattern: bearerPattern, replacement: "$1 " + RedactedText},
+			{name: "email", pattern: emailPattern, replacement: RedactedText},
+			{name: "card", pattern: cardPattern, replace: redactCardNumber},
+		},
+		sensitive: make(map[string]bool),
+	}
+	redactor.AddSensitiveFields(DefaultSensitiveFields...)
+	return redactor
+}
+
+// AddPattern adds a regular expression whose matches are replaced. The replacement can refer to
+// submatches like regexp.ReplaceAllString. An empty replacement is RedactedText.
+func (redactor *Redactor) AddPattern(name, expression, replacement string) error {
+	pattern, error := regexp.Compile(expression)
+	if error != nil {
+		return fmt.Errorf("invalid redaction pattern '%s': %w", name, error)
+	}
+	if replacement == "" {
+		replacement = RedactedText
+	}
+	redactor.mutex.Lock()
+	defer redactor.mutex.Unlock()
+	redactor.rules = append(redactor.rules, redactRule{name: name, pattern: pattern, replacement: replacement})
+	return nil
+}
+
+// RemovePattern removes the pattern with the name. The built in patterns are named "bearer",
+// "email", and "card".
+func (redactor *Redactor) RemovePattern(name string) {
+	redactor.mutex.Lock()
+	defer redactor.mutex.Unlock()
+	rules := make([]redactRule, 0, len(redactor.rules))
+	for _, rule := range redactor.rules {
+		if rule.name != name {
+			rules = append(rules, rule)
+		}
+	}
+	redactor.rules = rules
+}
+
+// AddSensitiveFields marks the field keys as sensitive. Their values are always masked. Keys are
+// matched without regard to case.
+func (redactor *Redactor) AddSensitiveFields(keys ...string) {
+	redactor.mutex.Lock()
+	defer redactor.mutex.Unlock()
+	for _, key := range keys {
+		redactor.sensitive[strings.ToLower(key)] = true
+	}
+}
+
+// Redact returns the string with the text that matches the patterns masked.
+func (redactor *Redactor) Redact(s string) string {
+	redactor.mutex.RLock()
+	defer redactor.mutex.RUnlock()
+	return redactor.redact(s)
+}
+
+// redact masks the string. The caller holds the mutex.
+func (redactor *Redactor) redact(s string) string {
+	for _, rule := range redactor.rules {
+		if rule.replace != nil {
+			s = rule.pattern.ReplaceAllStringFunc(s, rule.replace)
+		} else {
+			s = rule.pattern.ReplaceAllString(s, rule.replacement)
+		}
+	}
+	return s
+}
+
+// RedactEntry returns a copy of the entry with its message and fields masked, or the entry
+// itself if nothing is masked.
+func (redactor *Redactor) RedactEntry(entry *Entry) *Entry {
+	redactor.mutex.RLock()
+	defer redactor.mutex.RUnlock()
+	message := redactor.redact(entry.Message)
+	var fields []Field
+	for i, field := range entry.Fields {
+		value, changed := field.Value, false
+		if redactor.sensitive[strings.ToLower(field.Key)] {
+			value, changed = RedactedText, true
+		} else if _, ok := value.(sensitiveValue); !ok {
+			s := fieldValueString(value)
+			if redacted := redactor.redact(s); redacted != s {
+				value, changed = redacted, true
+			}
+		}
+		if changed && fields == nil {
+			fields = append(make([]Field, 0, len(entry.Fields)), entry.Fields[:i]...)
+		}
+		if fields != nil {
+			fields = append(fields, Field{Key: field.Key, Value: value})
+		}
+	}
+	if message == entry.Message && fields == nil {
+		return entry
+	}
+	result := *entry
+	result.Message = message
+	if fields != nil {
+		result.Fields = fields
+	}
+	return &result
+}
+
+// redactCardNumber masks a run of digits that passes the Luhn check used by card numbers.
+func redactCardNumber(s string) string {
+	sum, double := 0, false
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i] < '0' || s[i] > '9' {
+			continue
+		}
+		digit := int(s[i] - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	if sum%10 != 0 {
+		return s
+	}
+	return RedactedText
+}
+
+// SetRedactor sets the Redactor that masks log messages before they're written to the log file or
+// any sink. A nil Redactor turns redaction off, which is the default.
+func (logger *Logger) SetRedactor(redactor *Redactor) {
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	logger.redactor = redactor
+}
+
+// Redactor returns the Redactor that masks log messages, or nil if there isn't one.
+func (logger *Logger) Redactor() *Redactor {
+	logger.mutex.RLock()
+	defer logger.mutex.RUnlock()
+	return logger.redactor
+}
+
+// SetRedactor sets the Redactor of the default Logger.
+func SetRedactor(redactor *Redactor) { defaultLogger.SetRedactor(redactor) }
@@ -0,0 +1,114 @@
+/**
+@file          reopen.go
+@package       log
+@brief         Reopens the log file after an external tool such as logrotate moves or truncates it.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// Reopen closes and reopens the log file without rotating it. Call Reopen after an external tool
+// such as logrotate has moved the log file away.
+func (logger *Logger) Reopen() {
+	logger.drainQueue()
+	logger.writeMutex.Lock()
+	defer logger.writeMutex.Unlock()
+	logger.closeLogFile()
+	logger.openLogFile()
+}
+
+// Reopen closes and reopens the log file without rotating it. Call Reopen after an external tool
+// such as logrotate has moved the log file away.
+func Reopen() { defaultLogger.Reopen() }
+
+// ReopenOnSignal reopens the log file each time the process receives one of the signals. If no
+// signals are passed SIGHUP is used. Call the returned function to stop handling the signals.
+func (logger *Logger) ReopenOnSignal(signals ...os.Signal) (stop func()) {
+	if len(signals) == 0 {
+		signals = []os.Signal{syscall.SIGHUP}
+	}
+	c := make(chan os.Signal, 1)
+	done := make(chan bool)
+	signal.Notify(c, signals...)
+	go func() {
+		for {
+			select {
+			case sig := <-c:
+				logger.Reopen()
+				logger.Infof("Log reopened on signal %v.", sig)
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(c)
+		close(done)
+	}
+}
+
+// ReopenOnSignal reopens the log file each time the process receives one of the signals. If no
+// signals are passed SIGHUP is used. Call the returned function to stop handling the signals.
+func ReopenOnSignal(signals ...os.Signal) (stop func()) {
+	return defaultLogger.ReopenOnSignal(signals...)
+}
+
+// SetReopenCheckInterval turns on a check, made at most once every interval while writing, that
+// reopens the log file if it was moved, removed, or truncated. This makes the log safe to use with
+// logrotate's copytruncate mode. An interval of zero turns the check off.
+func (logger *Logger) SetReopenCheckInterval(interval time.Duration) {
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	logger.reopenCheckInterval = interval
+}
+
+// ReopenCheckInterval returns how often the log file is checked to see if it was moved or truncated.
+func (logger *Logger) ReopenCheckInterval() time.Duration {
+	logger.mutex.RLock()
+	defer logger.mutex.RUnlock()
+	return logger.reopenCheckInterval
+}
+
+// SetReopenCheckInterval turns on a check, made at most once every interval while writing, that
+// reopens the log file if it was moved, removed, or truncated. This makes the log safe to use with
+// logrotate's copytruncate mode. An interval of zero turns the check off.
+func SetReopenCheckInterval(interval time.Duration) { defaultLogger.SetReopenCheckInterval(interval) }
+
+// checkReopen reopens the log file if it's been moved, removed, or truncated since the last check.
+// The caller holds writeMutex.
+func (logger *Logger) checkReopen(now time.Time) {
+	interval := logger.ReopenCheckInterval()
+	if interval <= 0 || logger.filename == "" || now.Sub(logger.lastReopenCheck) < interval {
+		return
+	}
+	logger.lastReopenCheck = now
+
+	file, ok := logger.writer.(*os.File)
+	if !ok {
+		return
+	}
+	openInfo, error := file.Stat()
+	if error != nil {
+		return
+	}
+	nameInfo, error := os.Stat(logger.filename)
+	switch {
+	case error != nil || !os.SameFile(openInfo, nameInfo):
+		logger.closeLogFile()
+		logger.openLogFile()
+		logger.logLocked(LevelInfo, "Log file was moved. Reopened '%s'.", logger.filename)
+	case nameInfo.Size() < logger.fileSize:
+		logger.closeLogFile()
+		logger.openLogFile()
+		logger.logLocked(LevelInfo, "Log file was truncated. Reopened '%s'.", logger.filename)
+	}
+}
@@ -0,0 +1,89 @@
+/**
+@file          reopen_test.go
+@package       log
+@brief         Test reopening the log file after it's moved or truncated.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestReopen(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "reopen.log")
+	moved := filepath.Join(dir, "reopen.log.1")
+	logger := NewLogger()
+	logger.SetFilename(filename)
+	logger.Infof("Before move.")
+	os.Rename(filename, moved)
+	logger.Infof("After move.")
+	logger.Reopen()
+	logger.Infof("After reopen.")
+	logger.SetFilename("")
+
+	b, _ := os.ReadFile(moved)
+	if !strings.Contains(string(b), "After move.") || strings.Contains(string(b), "After reopen.") {
+		t.Errorf("Unexpected moved log:\n%s", b)
+	}
+	b, _ = os.ReadFile(filename)
+	if !strings.Contains(string(b), "After reopen.") {
+		t.Errorf("Unexpected reopened log:\n%s", b)
+	}
+}
+
+func TestReopenOnSignal(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "signal.log")
+	logger := NewLogger()
+	logger.SetFilename(filename)
+	stop := logger.ReopenOnSignal(syscall.SIGUSR1)
+	defer stop()
+
+	os.Rename(filename, filename+".1")
+	process, _ := os.FindProcess(os.Getpid())
+	process.Signal(syscall.SIGUSR1)
+	var b []byte
+	for i := 0; i < 100 && !strings.Contains(string(b), "Log reopened"); i++ {
+		time.Sleep(time.Millisecond * 10)
+		b, _ = os.ReadFile(filename)
+	}
+	logger.SetFilename("")
+	if !strings.Contains(string(b), "Log reopened on signal") {
+		t.Errorf("Expected the log to be reopened, found:\n%s", b)
+	}
+}
+
+func TestReopenCheck(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "check.log")
+	logger := NewLogger()
+	logger.SetFilename(filename)
+	logger.SetReopenCheckInterval(time.Nanosecond)
+
+	logger.Infof("First.")
+	os.Rename(filename, filename+".1")
+	logger.Infof("Second.")
+	b, _ := os.ReadFile(filename)
+	if !strings.Contains(string(b), "Log file was moved.") || !strings.Contains(string(b), "Second.") {
+		t.Errorf("Expected the moved log to be reopened, found:\n%s", b)
+	}
+
+	os.Truncate(filename, 0)
+	logger.Infof("Third.")
+	logger.SetFilename("")
+	b, _ = os.ReadFile(filename)
+	if !strings.Contains(string(b), "Log file was truncated.") ||
+		strings.Contains(string(b), "Second.") {
+		t.Errorf("Expected the truncated log to be reopened, found:\n%s", b)
+	}
+}
@@ -0,0 +1,153 @@
+/**
+@file          retention.go
+@package       log
+@brief         Removes old rotated log files by count, age, and total size.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// SetRetentionCount sets the number of rotated log files to keep. A count of zero or less keeps
+// any number of files, subject to the other retention limits. The default is one.
+func (logger *Logger) SetRetentionCount(count int) {
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	logger.retentionCount = count
+}
+
+// RetentionCount returns the number of rotated log files to keep.
+func (logger *Logger) RetentionCount() int {
+	logger.mutex.RLock()
+	defer logger.mutex.RUnlock()
+	return logger.retentionCount
+}
+
+// SetRetentionMaxAge sets the age at which rotated log files are removed. Zero means no age limit.
+func (logger *Logger) SetRetentionMaxAge(age time.Duration) {
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	logger.retentionMaxAge = age
+}
+
+// RetentionMaxAge returns the age at which rotated log files are removed.
+func (logger *Logger) RetentionMaxAge() time.Duration {
+	logger.mutex.RLock()
+	defer logger.mutex.RUnlock()
+	return logger.retentionMaxAge
+}
+
+// SetRetentionMaxBytes sets the total size in bytes of all rotated log files. The oldest files are
+// removed first until the rest fit. Zero means no size limit.
+func (logger *Logger) SetRetentionMaxBytes(size int64) {
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	logger.retentionMaxBytes = size
+}
+
+// RetentionMaxBytes returns the total size in bytes of all rotated log files.
+func (logger *Logger) RetentionMaxBytes() int64 {
+	logger.mutex.RLock()
+	defer logger.mutex.RUnlock()
+	return logger.retentionMaxBytes
+}
+
+// archiveMessage is a message about archiving that's logged after retentionMutex is released.
+type archiveMessage struct {
+	level   Level
+	message string
+}
+
+// logArchiveMessages writes the messages straight to the log, as the rotation messages are, so that
+// they can't rotate the log and start another round of archiving. The caller must not hold
+// writeMutex or retentionMutex.
+func (logger *Logger) logArchiveMessages(messages []archiveMessage) {
+	if len(messages) == 0 {
+		return
+	}
+	logger.writeMutex.Lock()
+	defer logger.writeMutex.Unlock()
+	for _, message := range messages {
+		logger.logLocked(message.level, "%s", message.message)
+	}
+}
+
+// removeOldLogFiles removes the rotated archives of the log file that are past the retention limits.
+func (logger *Logger) removeOldLogFiles(filename string) {
+	logger.logArchiveMessages(logger.removeOldLogFilesLocked(filename))
+}
+
+// removeOldLogFilesLocked removes the old log files while holding retentionMutex and returns the
+// messages to log once it's released.
+func (logger *Logger) removeOldLogFilesLocked(filename string) (messages []archiveMessage) {
+	logger.retentionMutex.Lock()
+	defer logger.retentionMutex.Unlock()
+
+	logfiles, error := rotatedLogFiles(filename)
+	debugMessagef("Log files: %+v.", logfiles)
+	if error != nil {
+		return []archiveMessage{{LevelError, error.Error()}}
+	}
+
+	//  Count a compressed file once and skip files being compressed --
+
+	names := make(map[string]bool, len(logfiles))
+	for _, logfile := range logfiles {
+		names[logfile] = true
+	}
+	archives := make([]string, 0, len(logfiles))
+	for _, logfile := range logfiles {
+		if names[logfile+compressSuffix] || logger.compressing[logfile] {
+			continue
+		}
+		archives = append(archives, logfile)
+	}
+	sortedLogfiles := sortLogArchives(archives)
+
+	//  Find the oldest file to keep --
+
+	keep := 0
+	if count := logger.RetentionCount(); count > 0 && len(sortedLogfiles) > count {
+		keep = len(sortedLogfiles) - count
+	}
+	if maxAge := logger.RetentionMaxAge(); maxAge > 0 {
+		oldest := time.Now().Add(-maxAge)
+		for i := keep; i < len(sortedLogfiles); i++ {
+			info, error := os.Stat(sortedLogfiles[i])
+			if error == nil && info.ModTime().After(oldest) {
+				break
+			}
+			keep = i + 1
+		}
+	}
+	if maxBytes := logger.RetentionMaxBytes(); maxBytes > 0 {
+		var total int64
+		for i := len(sortedLogfiles) - 1; i >= keep; i-- {
+			if info, error := os.Stat(sortedLogfiles[i]); error == nil {
+				total += info.Size()
+			}
+			if total > maxBytes {
+				keep = i + 1
+				break
+			}
+		}
+	}
+
+	//  Remove the older files --
+
+	for i := 0; i < keep; i++ {
+		messages = append(messages, archiveMessage{LevelInfo, fmt.Sprintf("Removing old log '%s'.", sortedLogfiles[i])})
+		error = os.Remove(sortedLogfiles[i])
+		if error != nil && !os.IsNotExist(error) {
+			messages = append(messages, archiveMessage{LevelError, fmt.Sprintf("Can't remove log file '%s': %v.", sortedLogfiles[i], error)})
+		}
+	}
+	return messages
+}
@@ -0,0 +1,128 @@
+/**
+@file          retention_test.go
+@package       log
+@brief         Test removal of old rotated log files.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// makeArchives makes count rotated log files of 100 bytes each, one day apart, with the newest last.
+func makeArchives(t *testing.T, dir string, count int) []string {
+	var archives []string
+	for i := 0; i < count; i++ {
+		archive := filepath.Join(dir, fmt.Sprintf("app-2026-10-%02dT09-30-00-07-00.log", i+1))
+		if i%2 == 1 {
+			archive += compressSuffix
+		}
+		if error := os.WriteFile(archive, []byte(strings.Repeat("x", 99)+"\n"), 0600); error != nil {
+			t.Fatal(error)
+		}
+		modTime := time.Now().Add(time.Duration(i-count) * time.Hour * 24)
+		os.Chtimes(archive, modTime, modTime)
+		archives = append(archives, archive)
+	}
+	return archives
+}
+
+func TestRetention(t *testing.T) {
+	tests := []struct {
+		count    int
+		maxAge   time.Duration
+		maxBytes int64
+		kept     int
+	}{
+		{count: 1, kept: 1},
+		{count: 4, kept: 4},
+		{count: 0, kept: 10},
+		{count: 0, maxAge: time.Hour * 24 * 3, kept: 2},
+		{count: 0, maxBytes: 550, kept: 5},
+		{count: 4, maxAge: time.Hour * 24 * 7, maxBytes: 250, kept: 2},
+	}
+	for _, test := range tests {
+		dir := t.TempDir()
+		archives := makeArchives(t, dir, 10)
+		logger := NewLogger()
+		logger.SetLogLevel(LevelError)
+		logger.SetRetentionCount(test.count)
+		logger.SetRetentionMaxAge(test.maxAge)
+		logger.SetRetentionMaxBytes(test.maxBytes)
+		logger.removeOldLogFiles(filepath.Join(dir, "app.log"))
+
+		remaining, _ := filepath.Glob(filepath.Join(dir, "app-*"))
+		if len(remaining) != test.kept {
+			t.Errorf("%+v: Expected %d files, found %d.", test, test.kept, len(remaining))
+			continue
+		}
+		for i, archive := range archives[len(archives)-test.kept:] {
+			if remaining[i] != archive {
+				t.Errorf("%+v: Expected '%s' to be kept.", test, archive)
+			}
+		}
+	}
+}
+
+func TestRetentionOtherLogs(t *testing.T) {
+	dir := t.TempDir()
+	makeArchives(t, dir, 3)
+	others := []string{"app-access.log", "app-2026-10-01.log", "app-2026-10-01T09-30-00Z.log.bak", "application.log"}
+	for _, other := range others {
+		os.WriteFile(filepath.Join(dir, other), []byte("Other log.\n"), 0600)
+	}
+	logger := NewLogger()
+	logger.SetLogLevel(LevelError)
+	logger.SetRetentionCount(1)
+	logger.removeOldLogFiles(filepath.Join(dir, "app.log"))
+
+	for _, other := range others {
+		if !fileExists(filepath.Join(dir, other)) {
+			t.Errorf("Expected '%s' to be kept.", other)
+		}
+	}
+	if remaining, _ := filepath.Glob(filepath.Join(dir, "app-2026-10-*-07-00.log*")); len(remaining) != 1 {
+		t.Errorf("Expected one archive, found %v.", remaining)
+	}
+}
+
+func TestRetentionWhileLogging(t *testing.T) {
+	dir := t.TempDir()
+	logger := NewLogger()
+	logger.SetMaxFileSize(200)
+	logger.SetRetentionCount(1)
+	logger.SetRotationInterval(0)
+	logger.SetFilename(filepath.Join(dir, "app.log"))
+	defer logger.SetFilename("")
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 50; i++ {
+			logger.Infof("Message %d.", i)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second * 10):
+		t.Fatal("Logging deadlocked while removing old log files.")
+	}
+
+	logfiles, _ := filepath.Glob(filepath.Join(dir, "app*"))
+	if len(logfiles) != 2 {
+		t.Errorf("Expected the log and one archive, found %v.", logfiles)
+	}
+	b, _ := os.ReadFile(filepath.Join(dir, "app.log"))
+	if !strings.Contains(string(b), "Removing old log '") {
+		t.Errorf("Expected the removal to be logged:\n%s", b)
+	}
+}
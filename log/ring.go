@@ -0,0 +1,299 @@
+/**
+@file          ring.go
+@package       log
+@brief         An in-memory ring buffer of recent log entries that can be queried and tailed.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ringSubscriberSize is the number of entries buffered for each tail subscriber. Entries are
+// dropped for a subscriber that falls behind.
+const ringSubscriberSize = 256
+
+// ringEntry is an entry kept by a RingSink with its encoded line.
+type ringEntry struct {
+	entry *Entry
+	line  []byte
+}
+
+// RingQuery selects entries from a RingSink. Zero values match everything.
+type RingQuery struct {
+	// Entries below MinLevel aren't returned.
+	MinLevel Level
+
+	// Only entries at or after Since and before Until are returned.
+	Since time.Time
+	Until time.Time
+
+	// Only entries whose log line contains Contains are returned.
+	Contains string
+
+	// At most Limit of the newest matching entries are returned.
+	Limit int
+}
+
+// Match returns true if the entry and its encoded line match the query, ignoring the limit.
+func (query *RingQuery) Match(entry *Entry, line []byte) bool {
+	return entry.Level >= query.MinLevel &&
+		(query.Since.IsZero() || !entry.Time.Before(query.Since)) &&
+		(query.Until.IsZero() || entry.Time.Before(query.Until)) &&
+		(query.Contains == "" || bytes.Contains(line, []byte(query.Contains)))
+}
+
+// RingSink is a Sink that keeps the most recent log entries in memory so they can be queried or
+// tailed, for instance from an admin HTTP server.
+type RingSink struct {
+	mutex       sync.RWMutex
+	entries     []ringEntry
+	next        int
+	full        bool
+	subscribers map[chan *Entry]*RingQuery
+}
+
+// NewRingSink returns a sink that keeps the last size entries.
+func NewRingSink(size int) *RingSink {
+	if size < 1 {
+		size = 1
+	}
+	return &RingSink{
+		entries:     make([]ringEntry, size),
+		subscribers: make(map[chan *Entry]*RingQuery),
+	}
+}
+
+// WriteEntry adds the entry to the ring, replacing the oldest entry if it's full.
+func (ring *RingSink) WriteEntry(entry *Entry, line []byte) error {
+	ring.mutex.Lock()
+	defer ring.mutex.Unlock()
+	ring.entries[ring.next] = ringEntry{entry: entry, line: line}
+	ring.next++
+	if ring.next == len(ring.entries) {
+		ring.next = 0
+		ring.full = true
+	}
+	for c, query := range ring.subscribers {
+		if query.Match(entry, line) {
+			select {
+			case c <- entry:
+			default:
+			}
+		}
+	}
+	return nil
+}
+
+// Flush does nothing since the ring is in memory.
+func (ring *RingSink) Flush() error {
+	return nil
+}
+
+// Close ends the tail subscriptions. The entries can still be queried.
+func (ring *RingSink) Close() error {
+	ring.mutex.Lock()
+	defer ring.mutex.Unlock()
+	for c := range ring.subscribers {
+		close(c)
+	}
+	ring.subscribers = make(map[chan *Entry]*RingQuery)
+	return nil
+}
+
+// Len returns the number of entries in the ring.
+func (ring *RingSink) Len() int {
+	ring.mutex.RLock()
+	defer ring.mutex.RUnlock()
+	if ring.full {
+		return len(ring.entries)
+	}
+	return ring.next
+}
+
+// Reset removes the entries from the ring.
+func (ring *RingSink) Reset() {
+	ring.mutex.Lock()
+	defer ring.mutex.Unlock()
+	for i := range ring.entries {
+		ring.entries[i] = ringEntry{}
+	}
+	ring.next = 0
+	ring.full = false
+}
+
+// Entries returns the entries that match the query, oldest first.
+func (ring *RingSink) Entries(query RingQuery) []*Entry {
+	var result []*Entry
+	ring.each(query, func(entry ringEntry) { result = append(result, entry.entry) })
+	return result
+}
+
+// Lines returns the encoded log lines of the entries that match the query, oldest first.
+func (ring *RingSink) Lines(query RingQuery) [][]byte {
+	var result [][]byte
+	ring.each(query, func(entry ringEntry) { result = append(result, entry.line) })
+	return result
+}
+
+// each calls f with the entries that match the query, oldest first.
+func (ring *RingSink) each(query RingQuery, f func(ringEntry)) {
+	ring.mutex.RLock()
+	defer ring.mutex.RUnlock()
+	var matches []ringEntry
+	start, count := 0, ring.next
+	if ring.full {
+		start, count = ring.next, len(ring.entries)
+	}
+	for i := 0; i < count; i++ {
+		entry := ring.entries[(start+i)%len(ring.entries)]
+		if query.Match(entry.entry, entry.line) {
+			matches = append(matches, entry)
+		}
+	}
+	if query.Limit > 0 && len(matches) > query.Limit {
+		matches = matches[len(matches)-query.Limit:]
+	}
+	for _, entry := range matches {
+		f(entry)
+	}
+}
+
+// Subscribe returns a channel that receives new entries that match the query, and a function
+// that ends the subscription. Entries are dropped if the channel isn't read quickly enough.
+func (ring *RingSink) Subscribe(query RingQuery) (entries <-chan *Entry, cancel func()) {
+	c := make(chan *Entry, ringSubscriberSize)
+	ring.mutex.Lock()
+	ring.subscribers[c] = &query
+	ring.mutex.Unlock()
+	return c, func() {
+		ring.mutex.Lock()
+		defer ring.mutex.Unlock()
+		if _, ok := ring.subscribers[c]; ok {
+			delete(ring.subscribers, c)
+			close(c)
+		}
+	}
+}
+
+// parseRingQuery returns the query described by the request's query values.
+func parseRingQuery(request *http.Request) (RingQuery, error) {
+	var query RingQuery
+	values := request.URL.Query()
+	if s := values.Get("level"); s != "" {
+		if query.MinLevel = LevelFromString(s); query.MinLevel == LevelInvalid {
+			return query, fmt.Errorf("invalid level '%s'", s)
+		}
+	}
+	parseTime := func(name string) (time.Time, error) {
+		s := values.Get(name)
+		if s == "" {
+			return time.Time{}, nil
+		}
+		if d, error := time.ParseDuration(s); error == nil {
+			return time.Now().Add(-d), nil
+		}
+		t, error := time.Parse(time.RFC3339Nano, s)
+		if error != nil {
+			return t, fmt.Errorf("invalid %s time '%s'", name, s)
+		}
+		return t, nil
+	}
+	var error error
+	if query.Since, error = parseTime("since"); error != nil {
+		return query, error
+	}
+	if query.Until, error = parseTime("until"); error != nil {
+		return query, error
+	}
+	query.Contains = values.Get("q")
+	if s := values.Get("limit"); s != "" {
+		if query.Limit, error = strconv.Atoi(s); error != nil {
+			return query, fmt.Errorf("invalid limit '%s'", s)
+		}
+	}
+	return query, nil
+}
+
+// Handler returns an http.Handler that returns the entries in the ring as JSON lines. These query
+// values select the entries:
+//
+//	level=warning       Entries at or above the level.
+//	since=<time>        Entries at or after the RFC 3339 time, or the duration ago, such as 5m.
+//	until=<time>        Entries before the time or duration ago.
+//	q=<text>            Entries whose log line contains the text.
+//	limit=<n>           The newest n matching entries.
+//	format=text         Returns the log lines in the logger's format instead of JSON.
+//	follow=true         Tails new matching entries as Server-Sent Events.
+//
+// Requests that accept text/event-stream are also tailed.
+func (ring *RingSink) Handler() http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodGet && request.Method != http.MethodHead {
+			writer.Header().Set("Allow", "GET, HEAD")
+			http.Error(writer, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		query, error := parseRingQuery(request)
+		if error != nil {
+			http.Error(writer, error.Error(), http.StatusBadRequest)
+			return
+		}
+		follow, _ := strconv.ParseBool(request.URL.Query().Get("follow"))
+		if follow || request.Header.Get("Accept") == "text/event-stream" {
+			ring.serveEvents(writer, request, query)
+			return
+		}
+		writer.Header().Set("Cache-Control", "no-store")
+		if request.URL.Query().Get("format") == "text" {
+			writer.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			for _, line := range ring.Lines(query) {
+				writer.Write(line)
+			}
+			return
+		}
+		writer.Header().Set("Content-Type", "application/x-ndjson")
+		for _, entry := range ring.Entries(query) {
+			writer.Write(JSONEncoder{}.Encode(entry))
+		}
+	})
+}
+
+// serveEvents sends new entries that match the query as Server-Sent Events until the client goes away.
+func (ring *RingSink) serveEvents(writer http.ResponseWriter, request *http.Request, query RingQuery) {
+	flusher, ok := writer.(http.Flusher)
+	if !ok {
+		http.Error(writer, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+	query.Limit = 0
+	entries, cancel := ring.Subscribe(query)
+	defer cancel()
+	writer.Header().Set("Content-Type", "text/event-stream")
+	writer.Header().Set("Cache-Control", "no-store")
+	writer.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	for {
+		select {
+		case entry, ok := <-entries:
+			if !ok {
+				return
+			}
+			writer.Write([]byte("data: "))
+			writer.Write(bytes.TrimSuffix(JSONEncoder{}.Encode(entry), []byte{'\n'}))
+			writer.Write([]byte("\n\n"))
+			flusher.Flush()
+		case <-request.Context().Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,106 @@
+/**
+@file          ring_test.go
+@package       log
+@brief         Test the in-memory ring buffer of recent log entries.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRingSink(t *testing.T) {
+	ring := NewRingSink(4)
+	logger := NewLogger()
+	logger.SetFilename(filepath.Join(t.TempDir(), "ring.log"))
+	defer logger.SetFilename("")
+	logger.SetLogLevel(LevelDebug)
+	logger.AddSink(ring)
+
+	for i := 0; i < 6; i++ {
+		logger.Info(fmt.Sprintf("Message %d.", i), "i", i)
+	}
+	logger.Warning("Careful.")
+	if ring.Len() != 4 {
+		t.Errorf("Expected 4 entries, found %d.", ring.Len())
+	}
+	messages := func(entries []*Entry) string {
+		var result []string
+		for _, entry := range entries {
+			result = append(result, entry.Message)
+		}
+		return strings.Join(result, " ")
+	}
+	if s := messages(ring.Entries(RingQuery{})); s != "Message 3. Message 4. Message 5. Careful." {
+		t.Errorf("Unexpected entries '%s'.", s)
+	}
+	if s := messages(ring.Entries(RingQuery{MinLevel: LevelWarning})); s != "Careful." {
+		t.Errorf("Unexpected entries '%s'.", s)
+	}
+	if s := messages(ring.Entries(RingQuery{Contains: "i=4"})); s != "Message 4." {
+		t.Errorf("Unexpected entries '%s'.", s)
+	}
+	if s := messages(ring.Entries(RingQuery{Limit: 2})); s != "Message 5. Careful." {
+		t.Errorf("Unexpected entries '%s'.", s)
+	}
+	if n := len(ring.Entries(RingQuery{Since: time.Now().Add(time.Minute)})); n != 0 {
+		t.Errorf("Expected no entries, found %d.", n)
+	}
+
+	server := httptest.NewServer(ring.Handler())
+	defer server.Close()
+	response, error := http.Get(server.URL + "?level=warn&format=text")
+	if error != nil {
+		t.Fatal(error)
+	}
+	body, _ := io.ReadAll(response.Body)
+	response.Body.Close()
+	if !strings.HasSuffix(string(body), " Warn: Careful.\n") || strings.Count(string(body), "\n") != 1 {
+		t.Errorf("Unexpected body '%s'.", body)
+	}
+	if response, _ := http.Get(server.URL + "?since=yesterday"); response.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected a bad request, found %d.", response.StatusCode)
+	}
+}
+
+func TestRingSinkFollow(t *testing.T) {
+	ring := NewRingSink(10)
+	server := httptest.NewServer(ring.Handler())
+	defer server.Close()
+
+	response, error := http.Get(server.URL + "?follow=true&q=match")
+	if error != nil {
+		t.Fatal(error)
+	}
+	defer response.Body.Close()
+	if s := response.Header.Get("Content-Type"); s != "text/event-stream" {
+		t.Errorf("Unexpected content type '%s'.", s)
+	}
+	entry := testEntry()
+	entry.Message = "No."
+	ring.WriteEntry(entry, TextEncoder{}.Encode(entry))
+	entry = testEntry()
+	entry.Message = "A match."
+	ring.WriteEntry(entry, TextEncoder{}.Encode(entry))
+
+	reader := bufio.NewReader(response.Body)
+	line, error := reader.ReadString('\n')
+	if error != nil {
+		t.Fatal(error)
+	}
+	if !strings.HasPrefix(line, "data: {") || !strings.Contains(line, `"msg":"A match."`) {
+		t.Errorf("Unexpected event '%s'.", line)
+	}
+}
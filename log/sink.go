@@ -0,0 +1,99 @@
+/**
+@file          sink.go
+@package       log
+@brief         Sinks receive log entries in addition to the log file.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"io"
+)
+
+// Sink receives each log entry after it's written to the log file. The line is the entry as encoded
+// by the Logger's encoder. Sinks are called in order, one entry at a time, so a Sink shouldn't block.
+type Sink interface {
+	WriteEntry(entry *Entry, line []byte) error
+	Flush() error
+	Close() error
+}
+
+// AddSink adds a sink that receives each log entry in addition to the log file.
+func (logger *Logger) AddSink(sink Sink) {
+	defer logger.updateFlush()
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	logger.sinks = append(logger.sinks[:len(logger.sinks):len(logger.sinks)], sink)
+}
+
+// RemoveSink removes the sink from the logger. The sink isn't closed.
+func (logger *Logger) RemoveSink(sink Sink) {
+	defer logger.updateFlush()
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	sinks := make([]Sink, 0, len(logger.sinks))
+	for _, s := range logger.sinks {
+		if s != sink {
+			sinks = append(sinks, s)
+		}
+	}
+	logger.sinks = sinks
+}
+
+// Sinks returns the sinks that receive log entries.
+func (logger *Logger) Sinks() []Sink {
+	logger.mutex.RLock()
+	defer logger.mutex.RUnlock()
+	return logger.sinks
+}
+
+// AddSink adds a sink to the default Logger.
+func AddSink(sink Sink) { defaultLogger.AddSink(sink) }
+
+// RemoveSink removes the sink from the default Logger.
+func RemoveSink(sink Sink) { defaultLogger.RemoveSink(sink) }
+
+// flushSinks flushes each of the logger's sinks.
+func (logger *Logger) flushSinks() {
+	for _, sink := range logger.Sinks() {
+		sink.Flush()
+	}
+}
+
+// WriterSink is a Sink that writes each encoded line to an io.Writer.
+type WriterSink struct {
+	writer io.Writer
+}
+
+// NewWriterSink returns a sink that writes log lines to the writer.
+func NewWriterSink(writer io.Writer) *WriterSink {
+	return &WriterSink{writer: writer}
+}
+
+// WriteEntry writes the encoded line.
+func (sink *WriterSink) WriteEntry(_ *Entry, line []byte) error {
+	_, error := sink.writer.Write(line)
+	return error
+}
+
+// Flush flushes the writer if it has a Flush or Sync method.
+func (sink *WriterSink) Flush() error {
+	switch w := sink.writer.(type) {
+	case interface{ Flush() error }:
+		return w.Flush()
+	case interface{ Sync() error }:
+		return w.Sync()
+	}
+	return nil
+}
+
+// Close closes the writer if it's an io.Closer.
+func (sink *WriterSink) Close() error {
+	if closer, ok := sink.writer.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+/**
+@file          slog.go
+@package       log
+@brief         A log/slog Handler that writes through a gokit Logger.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+)
+
+const (
+	// SlogLevelStart is the slog level for an app start message.
+	SlogLevelStart = slog.Level(1)
+
+	// SlogLevelExit is the slog level for an app exit message.
+	SlogLevelExit = slog.Level(2)
+)
+
+// LevelFromSlogLevel returns the log Level for a slog level.
+func LevelFromSlogLevel(level slog.Level) Level {
+	switch {
+	case level < slog.LevelInfo:
+		return LevelDebug
+	case level < SlogLevelStart:
+		return LevelInfo
+	case level < SlogLevelExit:
+		return LevelStart
+	case level < slog.LevelWarn:
+		return LevelExit
+	case level < slog.LevelError:
+		return LevelWarning
+	default:
+		return LevelError
+	}
+}
+
+// SlogLevelFromLevel returns the slog level for a log Level.
+func SlogLevelFromLevel(level Level) slog.Level {
+	switch level {
+	case LevelInvalid, LevelAll, LevelDebug:
+		return slog.LevelDebug
+	case LevelInfo:
+		return slog.LevelInfo
+	case LevelStart:
+		return SlogLevelStart
+	case LevelExit:
+		return SlogLevelExit
+	case LevelWarning:
+		return slog.LevelWarn
+	default:
+		return slog.LevelError
+	}
+}
+
+// SlogHandler is a slog.Handler that writes records through a Logger, so slog output shares the
+// Logger's level, encoder, file, and rotation.
+type SlogHandler struct {
+	logger *Logger
+	fields []Field
+	group  string
+}
+
+// NewSlogHandler returns a slog.Handler that writes to the logger. If logger is nil the
+// default Logger is used.
+func NewSlogHandler(logger *Logger) *SlogHandler {
+	if logger == nil {
+		logger = defaultLogger
+	}
+	return &SlogHandler{logger: logger}
+}
+
+// Enabled reports whether the Logger's level or any of its level overrides allows messages at the slog level.
+func (handler *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return LevelFromSlogLevel(level) >= handler.logger.minLevel()
+}
+
+// Handle writes the record to the log with the fields extracted from the context.
+func (handler *SlogHandler) Handle(ctx context.Context, record slog.Record) error {
+	level := LevelFromSlogLevel(record.Level)
+	if !handler.logger.enabledForPC(level, record.PC) {
+		return nil
+	}
+	fields := make([]Field, 0, len(handler.logger.fields)+len(handler.fields)+record.NumAttrs())
+	fields = append(fields, handler.logger.fields...)
+	fields = appendFields(fields, contextFields(ctx))
+	fields = append(fields, handler.fields...)
+	record.Attrs(func(attr slog.Attr) bool {
+		fields = appendSlogAttr(fields, handler.group, attr)
+		return true
+	})
+	entry := &Entry{
+		Time:    record.Time,
+		Level:   level,
+		PC:      record.PC,
+		Message: record.Message,
+		Fields:  fields,
+	}
+	if record.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+		entry.File, entry.Line = frame.File, frame.Line
+	}
+	handler.logger.writeEntry(entry)
+	return nil
+}
+
+// WithAttrs returns a handler that adds the attributes to each record.
+func (handler *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	result := *handler
+	result.fields = append([]Field(nil), handler.fields...)
+	for _, attr := range attrs {
+		result.fields = appendSlogAttr(result.fields, handler.group, attr)
+	}
+	return &result
+}
+
+// WithGroup returns a handler that prefixes the keys of subsequent attributes with the group name.
+func (handler *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return handler
+	}
+	result := *handler
+	result.group = handler.group + name + "."
+	return &result
+}
+
+// appendSlogAttr appends the attribute as fields, flattening groups into dotted keys.
+func appendSlogAttr(fields []Field, group string, attr slog.Attr) []Field {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return fields
+	}
+	if attr.Value.Kind() == slog.KindGroup {
+		prefix := group
+		if attr.Key != "" {
+			prefix += attr.Key + "."
+		}
+		for _, a := range attr.Value.Group() {
+			fields = appendSlogAttr(fields, prefix, a)
+		}
+		return fields
+	}
+	return append(fields, Field{Key: group + attr.Key, Value: attr.Value.Any()})
+}
@@ -0,0 +1,71 @@
+/**
+@file          slog_test.go
+@package       log
+@brief         Test the log/slog Handler.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSlogLevels(t *testing.T) {
+	tests := []struct {
+		slog  slog.Level
+		level Level
+	}{
+		{slog.LevelDebug, LevelDebug},
+		{slog.LevelInfo, LevelInfo},
+		{SlogLevelStart, LevelStart},
+		{SlogLevelExit, LevelExit},
+		{slog.LevelWarn, LevelWarning},
+		{slog.LevelError, LevelError},
+	}
+	for _, test := range tests {
+		if level := LevelFromSlogLevel(test.slog); level != test.level {
+			t.Errorf("Expected %s for %v but found %s.", StringFromLevel(test.level), test.slog, StringFromLevel(level))
+		}
+		if s := SlogLevelFromLevel(test.level); s != test.slog {
+			t.Errorf("Expected %v for %s but found %v.", test.slog, StringFromLevel(test.level), s)
+		}
+	}
+}
+
+func TestSlogHandler(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "slog.log")
+	logger := NewLogger()
+	logger.SetFilename(filename)
+	logger.SetLogLevel(LevelInfo)
+
+	s := slog.New(NewSlogHandler(logger))
+	s.Debug("Filtered.")
+	logger.Infof("Legacy.")
+	s.With("shard", 3).WithGroup("req").Info("Slog.", "id", "r1", slog.Group("user", "name", "jo"))
+	s.Log(context.Background(), SlogLevelStart, "Started.")
+	logger.SetFilename("")
+
+	b, _ := os.ReadFile(filename)
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines, found:\n%s", b)
+	}
+	if !strings.HasSuffix(lines[0], " Info: Legacy.") {
+		t.Errorf("Unexpected line '%s'.", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], " Info: Slog. shard=3 req.id=r1 req.user.name=jo") ||
+		!strings.Contains(lines[1], "log/slog_test.go:") {
+		t.Errorf("Unexpected line '%s'.", lines[1])
+	}
+	if !strings.HasSuffix(lines[2], "Start: Started.") {
+		t.Errorf("Unexpected line '%s'.", lines[2])
+	}
+}
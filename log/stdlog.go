@@ -0,0 +1,129 @@
+/**
+@file          stdlog.go
+@package       log
+@brief         Routes the standard library log package and other writers into the log.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"bytes"
+	"io"
+	stdlog "log"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxPartialLine is the length at which text without a newline is logged anyway.
+const maxPartialLine = 64 * 1024
+
+// levelWriterFunction is the prefix of the names of the levelWriter methods, which are skipped
+// when finding the caller.
+var levelWriterFunction = func() string {
+	pc, _, _, _ := runtime.Caller(0)
+	return packagePath(runtime.FuncForPC(pc).Name()) + ".(*levelWriter)."
+}()
+
+// levelWriter is an io.Writer that logs each line written to it.
+type levelWriter struct {
+	logger  *Logger
+	level   Level
+	mutex   sync.Mutex
+	partial []byte
+}
+
+// NewLevelWriter returns a writer that logs each line written to it as a message at the level. Use
+// it to log the output of other code, such as an exec.Cmd's Stderr. The caller of each message is
+// the code that wrote it, skipping the standard library log package.
+func (logger *Logger) NewLevelWriter(level Level) io.Writer {
+	return &levelWriter{logger: logger, level: level}
+}
+
+// NewLevelWriter returns a writer that logs each line written to it to the default Logger.
+func NewLevelWriter(level Level) io.Writer { return defaultLogger.NewLevelWriter(level) }
+
+// NewStdLogger returns a standard library logger that writes its messages to the logger at the
+// level, for APIs such as http.Server.ErrorLog.
+func (logger *Logger) NewStdLogger(level Level) *stdlog.Logger {
+	return stdlog.New(logger.NewLevelWriter(level), "", 0)
+}
+
+// NewStdLogger returns a standard library logger that writes to the default Logger.
+func NewStdLogger(level Level) *stdlog.Logger { return defaultLogger.NewStdLogger(level) }
+
+// RedirectStdLog sends the messages written with the standard library log package to the logger
+// at the level. The standard logger's prefix and flags are cleared since the logger adds its own.
+// Call the returned function to restore the standard logger.
+func (logger *Logger) RedirectStdLog(level Level) (restore func()) {
+	output, prefix, flags := stdlog.Writer(), stdlog.Prefix(), stdlog.Flags()
+	stdlog.SetOutput(logger.NewLevelWriter(level))
+	stdlog.SetPrefix("")
+	stdlog.SetFlags(0)
+	return func() {
+		stdlog.SetOutput(output)
+		stdlog.SetPrefix(prefix)
+		stdlog.SetFlags(flags)
+	}
+}
+
+// RedirectStdLog sends the messages written with the standard library log package to the default Logger.
+func RedirectStdLog(level Level) (restore func()) { return defaultLogger.RedirectStdLog(level) }
+
+// Write logs each complete line. Text after the last newline is held until the rest of its line
+// is written.
+func (writer *levelWriter) Write(p []byte) (int, error) {
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+	writer.partial = append(writer.partial, p...)
+	for {
+		i := bytes.IndexByte(writer.partial, '\n')
+		if i < 0 {
+			break
+		}
+		writer.logLine(string(writer.partial[:i]))
+		writer.partial = writer.partial[i+1:]
+	}
+	if len(writer.partial) >= maxPartialLine {
+		writer.logLine(string(writer.partial))
+		writer.partial = nil
+	}
+	if len(writer.partial) == 0 {
+		writer.partial = nil
+	}
+	return len(p), nil
+}
+
+// logLine logs a line, billed to the first caller outside of this writer and the standard log package.
+func (writer *levelWriter) logLine(line string) {
+	line = strings.TrimRight(line, "\r")
+	if strings.TrimSpace(line) == "" {
+		return
+	}
+	entry := &Entry{
+		Time:    time.Now(),
+		Level:   writer.level,
+		Message: line,
+		Fields:  writer.logger.fields,
+	}
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, levelWriterFunction) && !strings.HasPrefix(frame.Function, "log.") {
+			entry.PC, entry.File, entry.Line = frame.PC+1, frame.File, frame.Line
+			break
+		}
+		if !more {
+			break
+		}
+	}
+	if !writer.logger.enabledForPC(entry.Level, entry.PC) {
+		return
+	}
+	writer.logger.writeEntry(entry)
+}
@@ -0,0 +1,57 @@
+/**
+@file          stdlog_test.go
+@package       log
+@brief         Test routing the standard library log package into the log.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"fmt"
+	stdlog "log"
+	"strings"
+	"testing"
+)
+
+func TestRedirectStdLog(t *testing.T) {
+	logger, ring := newTestRingLogger(t)
+	flags := stdlog.Flags()
+	restore := logger.RedirectStdLog(LevelWarning)
+	stdlog.Printf("Deprecated %s.", "option")
+	logger.NewStdLogger(LevelError).Println("Server error.")
+	restore()
+	if stdlog.Flags() != flags {
+		t.Errorf("Expected the standard logger's flags to be restored.")
+	}
+
+	entries := ring.Entries(RingQuery{})
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, found %d.", len(entries))
+	}
+	if entries[0].Level != LevelWarning || entries[0].Message != "Deprecated option." ||
+		entries[0].Caller() != "log/stdlog_test.go" || entries[0].Line != 23 {
+		t.Errorf("Unexpected entry %s:%d %+v.", entries[0].Caller(), entries[0].Line, entries[0])
+	}
+	if entries[1].Level != LevelError || entries[1].Line != 24 {
+		t.Errorf("Unexpected entry %s:%d %+v.", entries[1].Caller(), entries[1].Line, entries[1])
+	}
+}
+
+func TestLevelWriter(t *testing.T) {
+	logger, ring := newTestRingLogger(t)
+	writer := logger.NewLevelWriter(LevelInfo)
+	fmt.Fprint(writer, "one\ntw")
+	fmt.Fprint(writer, "o\r\n\nthree")
+	if n := len(ring.Entries(RingQuery{})); n != 2 {
+		t.Errorf("Expected 2 entries, found %d.", n)
+	}
+	fmt.Fprint(writer, strings.Repeat("x", maxPartialLine))
+	entries := ring.Entries(RingQuery{})
+	if len(entries) != 3 || entries[0].Message != "one" || entries[1].Message != "two" ||
+		entries[2].Message != "three"+strings.Repeat("x", maxPartialLine) {
+		t.Errorf("Unexpected entries %d.", len(entries))
+	}
+}
@@ -0,0 +1,235 @@
+/**
+@file          syslog.go
+@package       log
+@brief         A sink that writes RFC 5424 or RFC 3164 messages to syslog.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// SyslogFormat is the message format written by a SyslogSink.
+type SyslogFormat int32
+
+const (
+	// SyslogRFC5424 formats messages as described in RFC 5424, with the fields as structured data.
+	SyslogRFC5424 SyslogFormat = iota
+
+	// SyslogRFC3164 formats messages in the traditional BSD syslog format described in RFC 3164.
+	SyslogRFC3164
+)
+
+// SyslogFacility is the syslog facility of the messages written by a SyslogSink.
+type SyslogFacility int32
+
+// Syslog facilities.
+const (
+	FacilityKernel SyslogFacility = 0
+	FacilityUser   SyslogFacility = 1
+	FacilityDaemon SyslogFacility = 3
+	FacilityAuth   SyslogFacility = 4
+	FacilityLocal0 SyslogFacility = 16
+	FacilityLocal1 SyslogFacility = 17
+	FacilityLocal2 SyslogFacility = 18
+	FacilityLocal3 SyslogFacility = 19
+	FacilityLocal4 SyslogFacility = 20
+	FacilityLocal5 SyslogFacility = 21
+	FacilityLocal6 SyslogFacility = 22
+	FacilityLocal7 SyslogFacility = 23
+)
+
+// syslogStructuredDataID is the RFC 5424 structured data ID for the entry's caller and fields.
+// 32473 is the private enterprise number reserved for documentation and examples.
+const syslogStructuredDataID = "gokit@32473"
+
+// SyslogSeverityFromLevel returns the syslog severity for a log Level. Start and exit messages are notices.
+func SyslogSeverityFromLevel(level Level) int {
+	switch level {
+	case LevelDebug, LevelAll:
+		return 7
+	case LevelInfo:
+		return 6
+	case LevelStart, LevelExit:
+		return 5
+	case LevelWarning:
+		return 4
+	default:
+		return 3
+	}
+}
+
+// SyslogSink is a Sink that writes log entries to a syslog daemon over a Unix datagram or stream
+// socket, or over the network.
+type SyslogSink struct {
+	network  string
+	address  string
+	format   SyslogFormat
+	facility SyslogFacility
+	tag      string
+	hostname string
+	pid      int
+
+	mutex sync.Mutex
+	conn  net.Conn
+}
+
+// NewSyslogSink returns a sink that writes to syslog. The network is "unixgram" or "unix" for a local
+// syslog socket, or "udp" or "tcp". If the address is empty the local syslog socket is found, such
+// as /dev/log. Messages on stream connections end with a newline. The tag names the app in each
+// message and defaults to the program name.
+func NewSyslogSink(network, address string, format SyslogFormat, facility SyslogFacility, tag string) (*SyslogSink, error) {
+	if tag == "" {
+		tag = filepath.Base(os.Args[0])
+	}
+	hostname, _ := os.Hostname()
+	if hostname == "" {
+		hostname = "-"
+	}
+	sink := &SyslogSink{
+		network:  network,
+		address:  address,
+		format:   format,
+		facility: facility,
+		tag:      tag,
+		hostname: hostname,
+		pid:      os.Getpid(),
+	}
+	if error := sink.connect(); error != nil {
+		return nil, error
+	}
+	return sink, nil
+}
+
+// connect connects to the syslog daemon. The caller holds the mutex or owns the sink.
+func (sink *SyslogSink) connect() error {
+	if sink.address != "" {
+		conn, error := net.Dial(sink.network, sink.address)
+		if error != nil {
+			return error
+		}
+		sink.conn = conn
+		return nil
+	}
+	for _, address := range []string{"/dev/log", "/var/run/syslog", "/var/run/log"} {
+		for _, network := range []string{"unixgram", "unix"} {
+			if sink.network != "" && sink.network != network {
+				continue
+			}
+			if conn, error := net.Dial(network, address); error == nil {
+				sink.network, sink.address, sink.conn = network, address, conn
+				return nil
+			}
+		}
+	}
+	return errors.New("can't find the local syslog socket")
+}
+
+// Format returns the entry formatted as a syslog message without any framing.
+func (sink *SyslogSink) Format(entry *Entry) []byte {
+	priority := int(sink.facility)*8 + SyslogSeverityFromLevel(entry.Level)
+	message := strings.Replace(entry.Message, "\n", "|", -1)
+	if sink.format == SyslogRFC3164 {
+		if len(entry.Fields) > 0 {
+			message += " " + formatFields(entry.Fields)
+		}
+		return []byte(fmt.Sprintf("<%d>%s %s %s[%d]: %s",
+			priority,
+			entry.Time.Format("Jan _2 15:04:05"),
+			sink.hostname,
+			sink.tag,
+			sink.pid,
+			message,
+		))
+	}
+
+	var data strings.Builder
+	data.WriteString("[" + syslogStructuredDataID)
+	writeParam := func(name, value string) {
+		data.WriteString(" " + syslogParamName(name) + `="`)
+		data.WriteString(strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(value))
+		data.WriteString(`"`)
+	}
+	writeParam("caller", entry.Caller()+":"+strconv.Itoa(entry.Line))
+	for _, field := range entry.Fields {
+		writeParam(field.Key, fieldValueString(field.Value))
+	}
+	data.WriteString("]")
+	return []byte(fmt.Sprintf("<%d>1 %s %s %s %d - %s %s",
+		priority,
+		entry.Time.Format("2006-01-02T15:04:05.000000Z07:00"),
+		sink.hostname,
+		sink.tag,
+		sink.pid,
+		data.String(),
+		message,
+	))
+}
+
+// syslogParamName returns the name with the characters that RFC 5424 doesn't allow in a parameter
+// name replaced by underscores.
+func syslogParamName(name string) string {
+	result := []byte(name)
+	for i, c := range result {
+		if c <= ' ' || c >= 127 || c == '=' || c == ']' || c == '"' {
+			result[i] = '_'
+		}
+	}
+	if len(result) > 32 {
+		result = result[:32]
+	}
+	if len(result) == 0 {
+		return "_"
+	}
+	return string(result)
+}
+
+// WriteEntry writes the entry to syslog, reconnecting once if the write fails.
+func (sink *SyslogSink) WriteEntry(entry *Entry, _ []byte) error {
+	message := sink.Format(entry)
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	if sink.network != "unixgram" && sink.network != "udp" {
+		message = append(message, '\n')
+	}
+	if sink.conn != nil {
+		if _, error := sink.conn.Write(message); error == nil {
+			return nil
+		}
+		sink.conn.Close()
+		sink.conn = nil
+	}
+	if error := sink.connect(); error != nil {
+		return error
+	}
+	_, error := sink.conn.Write(message)
+	return error
+}
+
+// Flush does nothing since messages are written as they're received.
+func (sink *SyslogSink) Flush() error {
+	return nil
+}
+
+// Close closes the connection to syslog.
+func (sink *SyslogSink) Close() error {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	if sink.conn == nil {
+		return nil
+	}
+	error := sink.conn.Close()
+	sink.conn = nil
+	return error
+}
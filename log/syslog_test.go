@@ -0,0 +1,120 @@
+/**
+@file          syslog_test.go
+@package       log
+@brief         Test the syslog sink against a local socket.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"bufio"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// shortTempDir returns a temporary directory with a path short enough for a Unix socket.
+func shortTempDir(t *testing.T) string {
+	dir, error := os.MkdirTemp("", "log")
+	if error != nil {
+		t.Fatal(error)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+func TestSyslogSinkDatagram(t *testing.T) {
+	path := filepath.Join(shortTempDir(t), "syslog")
+	conn, error := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if error != nil {
+		t.Fatal(error)
+	}
+	defer conn.Close()
+
+	sink, error := NewSyslogSink("unixgram", path, SyslogRFC5424, FacilityLocal0, "test")
+	if error != nil {
+		t.Fatal(error)
+	}
+	defer sink.Close()
+	read := func() string {
+		buffer := make([]byte, 4096)
+		conn.SetReadDeadline(time.Now().Add(time.Second * 5))
+		n, error := conn.Read(buffer)
+		if error != nil {
+			t.Fatal(error)
+		}
+		return string(buffer[:n])
+	}
+
+	entry := testEntry()
+	entry.Level = LevelInfo
+	entry.Message = "Hello, world."
+	entry.Fields = []Field{{"user", `a "b"`}}
+	if error := sink.WriteEntry(entry, nil); error != nil {
+		t.Fatal(error)
+	}
+	message := read()
+	if !strings.HasPrefix(message, "<134>1 ") {
+		t.Errorf("Expected priority 134 but found '%s'.", message)
+	}
+	if !strings.Contains(message, ` test `) ||
+		!strings.Contains(message, `[gokit@32473 caller="scanner/scanner.go:42" user="a \"b\""] Hello, world.`) {
+		t.Errorf("Unexpected message '%s'.", message)
+	}
+
+	sink.format = SyslogRFC3164
+	entry.Level = LevelStart
+	sink.WriteEntry(entry, nil)
+	message = read()
+	if !strings.HasPrefix(message, "<133>") || !strings.HasSuffix(message, `: Hello, world. user="a \"b\""`) {
+		t.Errorf("Unexpected message '%s'.", message)
+	}
+}
+
+func TestSyslogSinkStream(t *testing.T) {
+	path := filepath.Join(shortTempDir(t), "syslog")
+	listener, error := net.Listen("unix", path)
+	if error != nil {
+		t.Fatal(error)
+	}
+	defer listener.Close()
+	lines := make(chan string, 2)
+	go func() {
+		conn, error := listener.Accept()
+		if error != nil {
+			return
+		}
+		defer conn.Close()
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+
+	sink, error := NewSyslogSink("unix", path, SyslogRFC3164, FacilityUser, "test")
+	if error != nil {
+		t.Fatal(error)
+	}
+	defer sink.Close()
+	entry := testEntry()
+	entry.Level = LevelWarning
+	sink.WriteEntry(entry, nil)
+	entry.Level = LevelError
+	sink.WriteEntry(entry, nil)
+	for _, prefix := range []string{"<12>", "<11>"} {
+		select {
+		case line := <-lines:
+			if !strings.HasPrefix(line, prefix) {
+				t.Errorf("Expected prefix %s but found '%s'.", prefix, line)
+			}
+		case <-time.After(time.Second * 5):
+			t.Fatal("Timed out.")
+		}
+	}
+}
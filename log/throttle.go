@@ -0,0 +1,222 @@
+/**
+@file          throttle.go
+@package       log
+@brief         Rate limits, samples, and collapses repeated log messages.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// repeatSummaryInterval is the longest time that repeated messages are held back before the number
+// of repeats is written, as syslog does.
+const repeatSummaryInterval = time.Second * 30
+
+// tokenBucket limits the messages written from one call site.
+type tokenBucket struct {
+	tokens     float64
+	last       time.Time
+	suppressed int
+}
+
+// throttle is the rate limiting, sampling, and duplicate suppression state of a logger.
+type throttle struct {
+	mutex sync.Mutex
+
+	// Messages from each call site are limited to rateLimit a second with bursts of rateBurst.
+	rateLimit float64
+	rateBurst int
+	buckets   map[uintptr]*tokenBucket
+
+	// The fraction of messages at each level that are written. Levels without a rate are all written.
+	sampleRates map[Level]float64
+
+	// Identical consecutive messages are counted instead of written when collapseRepeats is true.
+	collapseRepeats bool
+	lastEntry       *Entry
+	lastKey         string
+	repeats         int
+	repeatStart     time.Time
+
+	suppressed uint64
+}
+
+// SetRateLimit limits the messages written from each line of code to perSecond messages a second,
+// with bursts of up to burst messages. The next message written from a line after messages were
+// suppressed has a "suppressed" field with the number of suppressed messages. A perSecond of zero
+// turns off rate limiting.
+func (logger *Logger) SetRateLimit(perSecond float64, burst int) {
+	if burst < 1 {
+		burst = 1
+	}
+	logger.throttle.mutex.Lock()
+	defer logger.throttle.mutex.Unlock()
+	logger.throttle.rateLimit = perSecond
+	logger.throttle.rateBurst = burst
+	logger.throttle.buckets = nil
+}
+
+// RateLimit returns the number of messages a second and the burst size allowed from each line of code.
+func (logger *Logger) RateLimit() (perSecond float64, burst int) {
+	logger.throttle.mutex.Lock()
+	defer logger.throttle.mutex.Unlock()
+	return logger.throttle.rateLimit, logger.throttle.rateBurst
+}
+
+// SetSampleRate sets the fraction of the messages at the level that are written, chosen at random.
+// Sampling is meant for high volume debug and info messages. A rate of one writes every message.
+func (logger *Logger) SetSampleRate(level Level, rate float64) {
+	logger.throttle.mutex.Lock()
+	defer logger.throttle.mutex.Unlock()
+	if logger.throttle.sampleRates == nil {
+		logger.throttle.sampleRates = make(map[Level]float64)
+	}
+	if rate >= 1 {
+		delete(logger.throttle.sampleRates, level)
+	} else {
+		logger.throttle.sampleRates[level] = rate
+	}
+}
+
+// SampleRate returns the fraction of the messages at the level that are written.
+func (logger *Logger) SampleRate(level Level) float64 {
+	logger.throttle.mutex.Lock()
+	defer logger.throttle.mutex.Unlock()
+	if rate, ok := logger.throttle.sampleRates[level]; ok {
+		return rate
+	}
+	return 1
+}
+
+// SetCollapseRepeats when set to true writes identical consecutive messages once, followed by a
+// "Last message repeated N times." message when a different message is written, when the log is
+// flushed, or every 30 seconds while the message keeps repeating.
+func (logger *Logger) SetCollapseRepeats(value bool) {
+	logger.flushRepeats()
+	logger.throttle.mutex.Lock()
+	defer logger.throttle.mutex.Unlock()
+	logger.throttle.collapseRepeats = value
+	logger.throttle.lastKey = ""
+}
+
+// CollapseRepeats returns true if identical consecutive messages are collapsed.
+func (logger *Logger) CollapseRepeats() bool {
+	logger.throttle.mutex.Lock()
+	defer logger.throttle.mutex.Unlock()
+	return logger.throttle.collapseRepeats
+}
+
+// SuppressedMessages returns the number of messages that weren't written because of rate limiting,
+// sampling, or because they repeated the previous message.
+func (logger *Logger) SuppressedMessages() uint64 {
+	logger.throttle.mutex.Lock()
+	defer logger.throttle.mutex.Unlock()
+	return logger.throttle.suppressed
+}
+
+// SetRateLimit limits the messages written from each line of code by the default Logger.
+func SetRateLimit(perSecond float64, burst int) { defaultLogger.SetRateLimit(perSecond, burst) }
+
+// SetSampleRate sets the fraction of the default Logger's messages at the level that are written.
+func SetSampleRate(level Level, rate float64) { defaultLogger.SetSampleRate(level, rate) }
+
+// SetCollapseRepeats sets whether the default Logger collapses identical consecutive messages.
+func SetCollapseRepeats(value bool) { defaultLogger.SetCollapseRepeats(value) }
+
+// allow returns the entry to write, or nil if it's suppressed. The returned summary, if any, is a
+// message about repeated messages that's written first.
+func (logger *Logger) allow(entry *Entry) (result *Entry, summary *Entry) {
+	t := &logger.throttle
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if rate, ok := t.sampleRates[entry.Level]; ok && rand.Float64() >= rate {
+		t.suppressed++
+		return nil, nil
+	}
+
+	if t.rateLimit > 0 && entry.PC != 0 {
+		if t.buckets == nil {
+			t.buckets = make(map[uintptr]*tokenBucket)
+		}
+		bucket := t.buckets[entry.PC]
+		if bucket == nil {
+			bucket = &tokenBucket{tokens: float64(t.rateBurst), last: entry.Time}
+			t.buckets[entry.PC] = bucket
+		}
+		bucket.tokens += entry.Time.Sub(bucket.last).Seconds() * t.rateLimit
+		if bucket.tokens > float64(t.rateBurst) {
+			bucket.tokens = float64(t.rateBurst)
+		}
+		bucket.last = entry.Time
+		if bucket.tokens < 1 {
+			bucket.suppressed++
+			t.suppressed++
+			return nil, nil
+		}
+		bucket.tokens--
+		if bucket.suppressed > 0 {
+			copied := *entry
+			copied.Fields = appendFields(entry.Fields, []interface{}{Field{Key: "suppressed", Value: bucket.suppressed}})
+			entry = &copied
+			bucket.suppressed = 0
+		}
+	}
+
+	if !t.collapseRepeats {
+		return entry, nil
+	}
+	key := strconv.Itoa(int(entry.Level)) + "\x00" + entry.Message + "\x00" + formatFields(entry.Fields)
+	if key == t.lastKey {
+		t.repeats++
+		t.suppressed++
+		if entry.Time.Sub(t.repeatStart) < repeatSummaryInterval {
+			return nil, nil
+		}
+		return nil, t.repeatSummary(entry.Time)
+	}
+	summary = t.repeatSummary(entry.Time)
+	t.lastEntry, t.lastKey = entry, key
+	return entry, summary
+}
+
+// repeatSummary returns a message with the number of times the last message was repeated, or nil
+// if it wasn't. It starts a new count. The caller holds the mutex.
+func (t *throttle) repeatSummary(now time.Time) *Entry {
+	repeats := t.repeats
+	t.repeats, t.repeatStart = 0, now
+	if repeats == 0 || t.lastEntry == nil {
+		return nil
+	}
+	message := "Last message repeated " + strconv.Itoa(repeats) + " times."
+	if repeats == 1 {
+		message = "Last message repeated once."
+	}
+	return &Entry{
+		Time:    now,
+		Level:   t.lastEntry.Level,
+		PC:      t.lastEntry.PC,
+		File:    t.lastEntry.File,
+		Line:    t.lastEntry.Line,
+		Message: message,
+		Fields:  t.lastEntry.Fields,
+	}
+}
+
+// flushRepeats writes the number of times the last message was repeated, if it was.
+func (logger *Logger) flushRepeats() {
+	logger.throttle.mutex.Lock()
+	summary := logger.throttle.repeatSummary(time.Now())
+	logger.throttle.mutex.Unlock()
+	if summary != nil {
+		logger.writeAllowedEntry(summary)
+	}
+}
@@ -0,0 +1,99 @@
+/**
+@file          throttle_test.go
+@package       log
+@brief         Test rate limiting, sampling, and collapsing repeated messages.
+@author        Edward Smith
+@date          October 2026
+@copyright     -©- Copyright © 2014-2026 Edward Smith, all rights reserved. -©-
+*/
+
+package log
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestRingLogger(t *testing.T) (*Logger, *RingSink) {
+	ring := NewRingSink(5000)
+	logger := NewLogger()
+	logger.SetFilename(filepath.Join(t.TempDir(), "test.log"))
+	t.Cleanup(func() { logger.SetFilename("") })
+	logger.SetLogLevel(LevelDebug)
+	logger.AddSink(ring)
+	return logger, ring
+}
+
+func TestRateLimit(t *testing.T) {
+	logger, ring := newTestRingLogger(t)
+	logger.SetRateLimit(1, 3)
+	for i := 0; i < 10; i++ {
+		logger.Errorf("Failed %d.", i)
+		logger.Infof("Other site.")
+	}
+	failed := ring.Entries(RingQuery{Contains: "Failed"})
+	if len(failed) != 3 || len(ring.Entries(RingQuery{Contains: "Other site."})) != 3 {
+		t.Fatalf("Expected 3 messages from each site, found %d.", len(failed))
+	}
+	if logger.SuppressedMessages() != 14 {
+		t.Errorf("Expected 14 suppressed messages, found %d.", logger.SuppressedMessages())
+	}
+
+	//  A refilled bucket reports the suppressed messages --
+
+	entry := *failed[2]
+	entry.Time = entry.Time.Add(time.Second * 2)
+	entry.Message = "Again."
+	logger.writeEntry(&entry)
+	again := ring.Entries(RingQuery{Contains: "Again."})
+	if len(again) != 1 || formatFields(again[0].Fields) != "suppressed=7" {
+		t.Errorf("Unexpected entries %+v.", again)
+	}
+}
+
+func TestSampleRate(t *testing.T) {
+	logger, ring := newTestRingLogger(t)
+	logger.SetSampleRate(LevelDebug, 0.25)
+	logger.SetSampleRate(LevelInfo, 0)
+	for i := 0; i < 1000; i++ {
+		logger.Debugf("Debug.")
+		logger.Infof("Info.")
+		logger.Warningf("Warning.")
+	}
+	debug := len(ring.Entries(RingQuery{Contains: "Debug."}))
+	if debug < 150 || debug > 350 {
+		t.Errorf("Expected about 250 debug messages, found %d.", debug)
+	}
+	if n := len(ring.Entries(RingQuery{Contains: "Info."})); n != 0 {
+		t.Errorf("Expected no info messages, found %d.", n)
+	}
+	if n := len(ring.Entries(RingQuery{Contains: "Warning."})); n != 1000 {
+		t.Errorf("Expected 1000 warnings, found %d.", n)
+	}
+	if logger.SampleRate(LevelWarning) != 1 || logger.SampleRate(LevelDebug) != 0.25 {
+		t.Errorf("Unexpected sample rates.")
+	}
+}
+
+func TestCollapseRepeats(t *testing.T) {
+	logger, ring := newTestRingLogger(t)
+	logger.SetCollapseRepeats(true)
+	for i := 0; i < 5; i++ {
+		logger.Errorf("Connection refused.")
+	}
+	logger.Info("Retrying.", "attempt", 1)
+	logger.Info("Retrying.", "attempt", 1)
+	logger.FlushMessages()
+	logger.Infof("Done.")
+
+	var messages []string
+	for _, entry := range ring.Entries(RingQuery{}) {
+		messages = append(messages, entry.Message)
+	}
+	r := "Connection refused.|Last message repeated 4 times.|Retrying.|Last message repeated once.|Done."
+	if s := strings.Join(messages, "|"); s != r {
+		t.Errorf("Expected\n%s\nbut found\n%s", r, s)
+	}
+}
@@ -10,10 +10,16 @@
 package log
 
 import (
+	"net"
+	"net/http"
 	"os"
 	"os/user"
 	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
+	"time"
 )
 
 // HomePath returns the path to the user's home directory.
@@ -43,3 +49,83 @@ func absolutePath(filename string) string {
 	filename = path.Clean(filename)
 	return filename
 }
+
+// fileExists returns true if a file exists at the path.
+func fileExists(filename string) bool {
+	_, error := os.Stat(filename)
+	return error == nil
+}
+
+// rotatedLogFiles returns the rotated archives of the log file, unsorted. Only names made by
+// rotateLogFile, base-<time>[-N]ext with an optional compressSuffix, are matched, so that the logs
+// of other loggers in the same directory, such as app-access.log next to app.log, aren't included.
+func rotatedLogFiles(filename string) ([]string, error) {
+	baseName := filepath.Base(filename)
+	ext := filepath.Ext(baseName)
+	baseName = strings.TrimSuffix(baseName, ext)
+	pattern, error := regexp.Compile(`^` + regexp.QuoteMeta(baseName) +
+		`-\d{4}-\d{2}-\d{2}T\d{2}-\d{2}-\d{2}(?:Z|-\d{2}-\d{2})(?:-\d+)?` +
+		regexp.QuoteMeta(ext) + `(?:` + regexp.QuoteMeta(compressSuffix) + `)?$`)
+	if error != nil {
+		return nil, error
+	}
+	logfiles, error := filepath.Glob(filepath.Join(filepath.Dir(filename), baseName+"-*"))
+	if error != nil {
+		return nil, error
+	}
+	archives := logfiles[:0]
+	for _, logfile := range logfiles {
+		if pattern.MatchString(filepath.Base(logfile)) {
+			archives = append(archives, logfile)
+		}
+	}
+	return archives, nil
+}
+
+// sortLogArchives sorts rotated log files from oldest to newest by modification time.
+// Files rotated in the same time slot have a sequence number appended to the name, so ties
+// are broken by the name length first and then by name.
+func sortLogArchives(filenames []string) []string {
+	modTimes := make(map[string]time.Time, len(filenames))
+	for _, filename := range filenames {
+		if info, error := os.Stat(filename); error == nil {
+			modTimes[filename] = info.ModTime()
+		}
+	}
+	sorted := append([]string(nil), filenames...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ti, tj := modTimes[sorted[i]], modTimes[sorted[j]]
+		if !ti.Equal(tj) {
+			return ti.Before(tj)
+		}
+		if len(sorted[i]) != len(sorted[j]) {
+			return len(sorted[i]) < len(sorted[j])
+		}
+		return sorted[i] < sorted[j]
+	})
+	return sorted
+}
+
+// IPAddressFromHTTPRequest returns the client IP address of an HTTP request: the first address in
+// the X-Forwarded-For header, or the remote address without its port. util.IPAddressFromHTTPRequest
+// returns the same address.
+func IPAddressFromHTTPRequest(httpRequest *http.Request) string {
+	if httpRequest == nil {
+		return ""
+	}
+	address := httpRequest.Header.Get("x-forwarded-for")
+	if address != "" {
+		addressArray := strings.Split(address, ",")
+		if len(addressArray) > 0 {
+			address = addressArray[0]
+			address = strings.TrimSpace(address)
+		}
+	}
+	if address == "" {
+		address = httpRequest.RemoteAddr
+		if host, _, error := net.SplitHostPort(address); error == nil {
+			address = host
+		}
+	}
+	return address
+}
@@ -20,6 +20,8 @@ import (
 	"strings"
 	"time"
 	"unicode/utf8"
+
+	"github.com/E-B-Smith/gokit/log"
 )
 
 // CleanStringPtr trims spaces and return a nil string if the string is empty.
@@ -254,32 +256,7 @@ func NewUUIDString() string {
 
 // IPAddressFromHTTPRequest returns a cleaned up IP address from an httpRequest header.
 func IPAddressFromHTTPRequest(httpRequest *http.Request) string {
-	if httpRequest == nil {
-		return ""
-	}
-	address := httpRequest.Header.Get("x-forwarded-for")
-	if address != "" {
-		addressArray := strings.Split(address, ",")
-		if len(addressArray) > 0 {
-			address = addressArray[0]
-			address = strings.TrimSpace(address)
-		}
-	}
-	if address == "" {
-		address = httpRequest.RemoteAddr
-		i := strings.IndexRune(address, ']')
-		if i < 0 {
-			i = strings.IndexRune(address, ':')
-			if i > 0 {
-				address = address[:i-1]
-			} else if i == 0 {
-				address = ""
-			}
-		} else {
-			address = address[:i]
-		}
-	}
-	return address
+	return log.IPAddressFromHTTPRequest(httpRequest)
 }
 
 // FirstNRunes returns a string up to `n` runes long.
@@ -11,6 +11,7 @@ package util
 
 import (
 	"fmt"
+	"net/http"
 	"testing"
 	"time"
 )
@@ -156,3 +157,14 @@ func TestHumanDuration(t *testing.T) {
 		t.Errorf("Expected '%s' but got '%s'.\n", e, s)
 	}
 }
+
+//----------------------------------------------------------------------------------------
+//                                                             TestIPAddressFromHTTPRequest
+//----------------------------------------------------------------------------------------
+
+func TestIPAddressFromHTTPRequest(t *testing.T) {
+	request := &http.Request{RemoteAddr: "10.0.0.1:1234", Header: http.Header{}}
+	if s := IPAddressFromHTTPRequest(request); s != "10.0.0.1" {
+		t.Errorf("Expected '10.0.0.1' but got '%s'.\n", s)
+	}
+}